@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/urfave/cli/v2"
+	"github.com/voidint/g/version"
+)
+
+var aliasNameRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_.-]*$`)
+
+// aliasesFile 返回记录版本别名的文件路径，文件内容为别名与版本（或版本约束）的JSON映射。
+func aliasesFile() string {
+	return filepath.Join(ghomeDir, "aliases")
+}
+
+func readAliases(filename string) (aliases map[string]string, err error) {
+	aliases = make(map[string]string)
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return aliases, nil
+		}
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &aliases); err != nil {
+		return nil, fmt.Errorf("malformed alias file %s: %w", filename, err)
+	}
+	return aliases, nil
+}
+
+func writeAliases(filename string, aliases map[string]string) error {
+	data, err := json.MarshalIndent(aliases, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, append(data, '\n'), 0644)
+}
+
+// expandAlias 返回别名所指代的版本（或版本约束）。vname不是别名时原样返回，ok为false。
+func expandAlias(vname string) (target string, ok bool) {
+	aliases, err := readAliases(aliasesFile())
+	if err != nil {
+		return vname, false
+	}
+	if target, ok = aliases[vname]; !ok {
+		return vname, false
+	}
+	return target, true
+}
+
+// validateVersionArg 检查vname是否为具体版本号、版本通道或版本约束
+func validateVersionArg(vname string) (err error) {
+	if _, err = version.New(vname); err != nil && !isVersionChannel(vname) {
+		if _, err = semver.NewConstraint(vname); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateAliasName 检查别名是否合法。别名不能与版本号、版本约束相混淆。
+func validateAliasName(name string) error {
+	if !aliasNameRegexp.MatchString(name) || validateVersionArg(name) == nil {
+		return fmt.Errorf("invalid alias name %q, an alias must start with a letter and must not look like a version", name)
+	}
+	return nil
+}
+
+func setAlias(ctx *cli.Context) (err error) {
+	name, target := ctx.Args().Get(0), ctx.Args().Get(1)
+	if name == "" || target == "" {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+	if err = validateAliasName(name); err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	if err = validateVersionArg(target); err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+
+	aliases, err := readAliases(aliasesFile())
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	aliases[name] = target
+	if err = writeAliases(aliasesFile(), aliases); err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	fmt.Printf("%s -> %s\n", name, target)
+	return nil
+}
+
+func listAliases(ctx *cli.Context) (err error) {
+	aliases, err := readAliases(aliasesFile())
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	if len(aliases) == 0 {
+		fmt.Printf("No alias defined yet\n\n")
+		return nil
+	}
+
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if dirName, err := resolveInstalled(aliases[name], ""); err == nil {
+			fmt.Printf("%s -> %s (go%s)\n", name, aliases[name], dirName)
+		} else {
+			fmt.Printf("%s -> %s (not installed)\n", name, aliases[name])
+		}
+	}
+	return nil
+}
+
+func removeAlias(ctx *cli.Context) (err error) {
+	name := ctx.Args().First()
+	if name == "" {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	aliases, err := readAliases(aliasesFile())
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	if _, ok := aliases[name]; !ok {
+		return cli.Exit(fmt.Sprintf("[g] Alias %q does not exist.", name), 1)
+	}
+	delete(aliases, name)
+	if err = writeAliases(aliasesFile(), aliases); err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	fmt.Printf("Removed alias %s\n", name)
+	return nil
+}
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_validateAliasName(t *testing.T) {
+	t.Run("合法的别名", func(t *testing.T) {
+		for _, name := range []string{"prod", "legacy", "ci", "team-a.v2"} {
+			assert.Nil(t, validateAliasName(name), name)
+		}
+	})
+
+	t.Run("非法的别名", func(t *testing.T) {
+		for _, name := range []string{"", "1.21.6", "~1.21", "latest", "-", "x", "1prod", "a b"} {
+			assert.NotNil(t, validateAliasName(name), name)
+		}
+	})
+}
+
+func Test_expandAlias(t *testing.T) {
+	oldGhomeDir := ghomeDir
+	t.Cleanup(func() { ghomeDir = oldGhomeDir })
+	ghomeDir = t.TempDir()
+	assert.Nil(t, writeAliases(aliasesFile(), map[string]string{"prod": "~1.21"}))
+
+	t.Run("展开别名", func(t *testing.T) {
+		target, ok := expandAlias("prod")
+		assert.True(t, ok)
+		assert.Equal(t, "~1.21", target)
+	})
+
+	t.Run("非别名原样返回", func(t *testing.T) {
+		target, ok := expandAlias("1.22.3")
+		assert.False(t, ok)
+		assert.Equal(t, "1.22.3", target)
+	})
+
+	t.Run("别名文件不存在", func(t *testing.T) {
+		aliases, err := readAliases(filepath.Join(t.TempDir(), "aliases"))
+		assert.Nil(t, err)
+		assert.Empty(t, aliases)
+	})
+}
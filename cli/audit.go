@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v2"
+	"github.com/voidint/g/pkg/vulndb"
+	"github.com/voidint/g/version"
+)
+
+// auditItem 已安装版本的漏洞审计结果
+type auditItem struct {
+	Version string      `json:"version"`
+	Arch    string      `json:"arch"`
+	InUse   bool        `json:"inUse"`
+	Vulns   []auditVuln `json:"vulns"`
+}
+
+// auditVuln 影响已安装版本的漏洞
+type auditVuln struct {
+	ID      string   `json:"id"`
+	Aliases []string `json:"aliases,omitempty"`
+	Summary string   `json:"summary,omitempty"`
+	Fixed   string   `json:"fixed,omitempty"` // 修复该漏洞的版本，为空表示尚无修复版本。
+}
+
+func audit(ctx *cli.Context) (err error) {
+	installed := installedVersions()
+	if vname := ctx.Args().First(); vname != "" && len(installed) > 0 {
+		target, _ := expandAlias(vname)
+		if installed, err = version.NewFinder(installed, version.WithFinderGoarch("")).FindAll(target); err != nil {
+			return cli.Exit(errstring(err), 1)
+		}
+	}
+	if len(installed) == 0 {
+		fmt.Printf("No version installed yet\n\n")
+		return nil
+	}
+
+	source := ctx.String("db")
+	if source == "" {
+		source = vulndb.DefaultSource
+	}
+	entries, err := vulndb.New(source).GoEntries()
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+
+	items := auditVersions(installed, entries)
+	inused := inuse(goroot)
+	for i := range items {
+		items[i].InUse = installedDirName(items[i].Version, items[i].Arch) == inused
+	}
+
+	switch ctx.String("output") {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "    ")
+		_ = enc.Encode(items)
+	default:
+		renderAudit(items, os.Stdout)
+	}
+
+	for _, item := range items {
+		if len(item.Vulns) > 0 {
+			return cli.Exit("", exitCodeVulnerable)
+		}
+	}
+	return nil
+}
+
+// auditVersions 返回各已安装版本受影响的漏洞
+func auditVersions(installed []*version.Version, entries []*vulndb.Entry) (items []auditItem) {
+	items = make([]auditItem, 0, len(installed))
+	for _, v := range installed {
+		goarch := installedArch(v)
+		item := auditItem{
+			Version: v.Name(),
+			Arch:    goarch,
+			Vulns:   []auditVuln{},
+		}
+		for _, entry := range entries {
+			if fixed, affected := entry.Affects(v.Name()); affected {
+				item.Vulns = append(item.Vulns, auditVuln{
+					ID:      entry.ID,
+					Aliases: entry.Aliases,
+					Summary: entry.Summary,
+					Fixed:   fixed,
+				})
+			}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func renderAudit(items []auditItem, out io.Writer) {
+	var affected int
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	for _, item := range items {
+		name := installedDirName(item.Version, item.Arch)
+		if item.InUse {
+			name += " (in use)"
+		}
+		if len(item.Vulns) == 0 {
+			_, _ = fmt.Fprintf(w, "%s: no known vulnerabilities\n", name)
+			continue
+		}
+		affected++
+		_, _ = fmt.Fprintf(w, "%s: %d vulnerabilities\n", name, len(item.Vulns))
+		for _, vuln := range item.Vulns {
+			fixed := "not fixed yet"
+			if vuln.Fixed != "" {
+				fixed = "fixed in " + vuln.Fixed
+			}
+			_, _ = fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", vuln.ID, strings.Join(vuln.Aliases, ","), fixed, vuln.Summary)
+		}
+	}
+	_ = w.Flush()
+
+	if affected > 0 {
+		_, _ = fmt.Fprintf(out, "\n%d of %d installed versions have known vulnerabilities\n", affected, len(items))
+	}
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/pkg/vulndb"
+	"github.com/voidint/g/version"
+)
+
+func Test_auditVersions(t *testing.T) {
+	entries := []*vulndb.Entry{
+		{
+			ID:      "GO-2023-2185",
+			Aliases: []string{"CVE-2023-45283"},
+			Affected: []vulndb.Affected{{
+				Package: vulndb.Package{Name: vulndb.ModuleStdlib},
+				Ranges: []vulndb.Range{{
+					Type: "SEMVER",
+					Events: []vulndb.Event{
+						{Introduced: "0"}, {Fixed: "1.20.11"},
+						{Introduced: "1.21.0-0"}, {Fixed: "1.21.4"},
+					},
+				}},
+			}},
+		},
+		{
+			ID: "GO-2099-0001",
+			Affected: []vulndb.Affected{{
+				Package: vulndb.Package{Name: vulndb.ModuleToolchain},
+				Ranges: []vulndb.Range{{
+					Type:   "SEMVER",
+					Events: []vulndb.Event{{Introduced: "1.22.0"}},
+				}},
+			}},
+		},
+		{
+			ID: "GO-2023-2102",
+			Affected: []vulndb.Affected{{
+				Package: vulndb.Package{Name: "golang.org/x/net"},
+				Ranges: []vulndb.Range{{
+					Type:   "SEMVER",
+					Events: []vulndb.Event{{Introduced: "0"}},
+				}},
+			}},
+		},
+	}
+
+	items := auditVersions([]*version.Version{
+		newInstalled("1.21.3"),
+		newInstalled("1.21.4"),
+		newInstalled("1.22.1"),
+	}, entries)
+
+	assert.Equal(t, []auditItem{
+		{
+			Version: "1.21.3",
+			Arch:    runtime.GOARCH,
+			Vulns:   []auditVuln{{ID: "GO-2023-2185", Aliases: []string{"CVE-2023-45283"}, Fixed: "1.21.4"}},
+		},
+		{
+			Version: "1.21.4",
+			Arch:    runtime.GOARCH,
+			Vulns:   []auditVuln{},
+		},
+		{
+			Version: "1.22.1",
+			Arch:    runtime.GOARCH,
+			Vulns:   []auditVuln{{ID: "GO-2099-0001"}},
+		},
+	}, items)
+}
@@ -6,12 +6,16 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
 	"github.com/urfave/cli/v2"
 	"github.com/voidint/g/build"
+	"github.com/voidint/g/collector"
 	"github.com/voidint/g/version"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -87,6 +91,8 @@ const (
 	experimentalEnv = "G_EXPERIMENTAL"
 	homeEnv         = "G_HOME"
 	mirrorEnv       = "G_MIRROR"
+	versionEnv      = "G_VERSION"
+	vulndbEnv       = "G_VULNDB"
 )
 
 const (
@@ -94,6 +100,24 @@ const (
 	mirrorSep = ","
 )
 
+// 特定失败场景下的退出码，便于脚本区分处理。
+const (
+	// exitCodePackageAmbiguous 存在多个候选安装包且未指定具体的安装包
+	exitCodePackageAmbiguous = 3
+	// exitCodeChecksumNotFound 安装包缺少校验和且未允许跳过校验
+	exitCodeChecksumNotFound = 4
+	// exitCodeOutdated 存在过期或已停止维护的已安装版本（g outdated --fail-on）
+	exitCodeOutdated = 5
+	// exitCodeVulnerable 存在受已知漏洞影响的已安装版本（g audit）
+	exitCodeVulnerable = 6
+)
+
+// interactive 返回标准输入是否为终端。非终端环境下（如CI、管道）不应弹出交互式菜单。
+func interactive() bool {
+	fd := os.Stdin.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
 // ghome 返回g根目录
 func ghome() (dir string) {
 	if experimental := os.Getenv(experimentalEnv); strings.EqualFold(experimental, "true") {
@@ -107,11 +131,14 @@ func ghome() (dir string) {
 
 // inuse 返回当前的go版本号
 func inuse(goroot string) (version string) {
-	p, _ := os.Readlink(goroot)
+	p, err := os.Readlink(goroot)
+	if err != nil {
+		return ""
+	}
 	return filepath.Base(p)
 }
 
-// installed 返回当前的已经安装的go版本号
+// installed 返回当前已经安装的go版本及其是否正在使用。键为安装目录名，非本机硬件架构的版本带有架构后缀，如'1.22.3-386'。
 func installed() (versions map[string]bool) {
 	dirs, err := os.ReadDir(versionsDir)
 	if err != nil {
@@ -124,57 +151,226 @@ func installed() (versions map[string]bool) {
 		if !d.IsDir() {
 			continue
 		}
-		vname := d.Name()
-		versions[vname] = (vname == inused)
+		versions[d.Name()] = d.Name() == inused
 	}
 
 	return
 }
 
+// remoteVersions 返回镜像站点（默认为官方站点）上的所有go版本
+func remoteVersions() (items []*version.Version, err error) {
+	c, err := collector.NewCollector(strings.Split(os.Getenv(mirrorEnv), mirrorSep)...)
+	if err != nil {
+		return nil, err
+	}
+	return c.AllVersions()
+}
+
+// remoteQueryTimeout 本地命令中可选的远程查询的超时时间
+const remoteQueryTimeout = 5 * time.Second
+
+// remoteVersionsWithin 在限定时间内返回镜像站点上的所有go版本，超时则返回错误。
+func remoteVersionsWithin(timeout time.Duration) (items []*version.Version, err error) {
+	type result struct {
+		items []*version.Version
+		err   error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		items, err := remoteVersions()
+		ch <- result{items: items, err: err}
+	}()
+
+	select {
+	case r := <-ch:
+		return r.items, r.err
+	case <-time.After(timeout):
+		return nil, fmt.Errorf("timed out after %s while fetching remote versions", timeout)
+	}
+}
+
+// archSep 已安装版本目录名中版本号与硬件架构的分隔符，如'1.22.3-386'。
+const archSep = "-"
+
+// installedDirName 返回已安装版本的目录名。本机硬件架构的版本直接以版本号命名，其余架构追加架构后缀，如'1.22.3-386'。
+func installedDirName(vname, goarch string) string {
+	if goarch == "" || goarch == runtime.GOARCH {
+		return vname
+	}
+	return vname + archSep + goarch
+}
+
+// parseInstalledDirName 从已安装版本的目录名中解析出版本号和硬件架构。后缀不是已知的硬件架构时，整个目录名均视为版本号。
+func parseInstalledDirName(name string) (vname, goarch string) {
+	if idx := strings.LastIndex(name, archSep); idx > 0 && version.IsKnownGoarch(name[idx+1:]) {
+		return name[:idx], name[idx+1:]
+	}
+	return name, runtime.GOARCH
+}
+
+// installedArch 返回installedVersions所返回的已安装版本的硬件架构
+func installedArch(v *version.Version) string {
+	if pkgs := v.Packages(); len(pkgs) > 0 && pkgs[0].GOARCH != "" {
+		return pkgs[0].GOARCH
+	}
+	return runtime.GOARCH
+}
+
+// latestPatch 返回fdr中与v同属一个次版本的最新稳定修订版本
+func latestPatch(fdr *version.Finder, v *version.Version) (*version.Version, error) {
+	return fdr.Find(fmt.Sprintf("~%s.0", minorLine(v)))
+}
+
+// installedVersions 返回已安装的go版本列表（升序）。每个版本仅包含一个代表其安装目录的安装包，同一版本的不同硬件架构互为独立的列表项。
+func installedVersions() (items []*version.Version) {
+	dirs, err := os.ReadDir(versionsDir)
+	if err != nil {
+		return nil
+	}
+
+	for _, d := range dirs {
+		if !d.IsDir() {
+			continue
+		}
+		vname, goarch := parseInstalledDirName(d.Name())
+		v, err := version.New(vname, version.WithPackages([]*version.Package{{
+			FileName: d.Name(),
+			Kind:     version.ArchiveKind,
+			GOOS:     runtime.GOOS,
+			GOARCH:   goarch,
+		}}))
+		if err != nil {
+			continue
+		}
+		items = append(items, v)
+	}
+	sort.Stable(version.Collection(items))
+	return items
+}
+
+// installedFinder 返回基于versionsDir中已安装版本的查找器。goarch为空时查找本机硬件架构的版本。
+func installedFinder(goarch string) *version.Finder {
+	if goarch == "" {
+		goarch = runtime.GOARCH
+	}
+	return version.NewFinder(installedVersions(), version.WithFinderGoarch(goarch))
+}
+
+// resolveInstalled 在已安装的版本中查找满足条件的版本，返回其安装目录名。vname 支持 version.Finder 所支持的各类版本标识。
+func resolveInstalled(vname, goarch string) (dirName string, err error) {
+	v, err := installedFinder(goarch).Find(vname)
+	if err != nil {
+		return "", err
+	}
+	return installedDirName(v.Name(), goarch), nil
+}
+
+// installedTarget 返回命令行参数所指代的已安装版本目录名。参数可以是别名、已安装版本（目录）名或版本约束，如'~1.21'。
+func installedTarget(vname, goarch string) (dirName string, err error) {
+	vname, _ = expandAlias(vname)
+	dirName = installedDirName(vname, goarch)
+	if finfo, err := os.Stat(filepath.Join(versionsDir, dirName)); err == nil && finfo.IsDir() {
+		return dirName, nil
+	}
+	return resolveInstalled(vname, goarch)
+}
+
 type versionOut struct {
 	Version   string            `json:"version"`
+	Arch      string            `json:"arch,omitempty"`
 	InUse     bool              `json:"inUse"`
 	Installed bool              `json:"installed"`
+	Support   string            `json:"support,omitempty"` // 维护状态，未知时为空。
 	Packages  []version.Package `json:"packages,omitempty"`
 }
 
+// dirName 返回版本对应的安装目录名
+func (vo *versionOut) dirName() string {
+	return installedDirName(vo.Version, vo.Arch)
+}
+
 const (
-	textMode = 0
-	jsonMode = 1
+	textMode  = 0
+	jsonMode  = 1
+	wideMode  = 2
+	tableMode = 3
 )
 
-// render 渲染go版本列表
-func render(mode uint8, installed map[string]bool, items []*version.Version, out io.Writer) {
-	switch mode {
-	case jsonMode:
-		vs := make([]versionOut, 0, len(items))
+// render 渲染go版本列表。installed的键为安装目录名，非本机硬件架构的已安装版本将作为独立的列表项紧随其后。
+// supported为官方仍在维护的次版本，为空时不渲染维护状态。
+func render(mode uint8, installed map[string]bool, supported []string, items []*version.Version, out io.Writer) {
+	// 按版本号归集非本机硬件架构的已安装版本
+	foreign := make(map[string][]string)
+	for dirName := range installed {
+		if vname, goarch := parseInstalledDirName(dirName); goarch != runtime.GOARCH {
+			foreign[vname] = append(foreign[vname], goarch)
+		}
+	}
 
-		for _, item := range items {
-			vo := versionOut{
-				Version:  item.Name(),
-				Packages: item.Packages(),
-			}
-			if inuse, found := installed[item.Name()]; found {
-				vo.InUse = inuse
-				vo.Installed = found
-			}
-			vs = append(vs, vo)
+	vs := make([]versionOut, 0, len(items))
+	for _, item := range items {
+		vo := versionOut{
+			Version:  item.Name(),
+			Packages: item.Packages(),
+			Support:  supportStatus(item, supported),
+		}
+		vo.InUse, vo.Installed = installed[installedDirName(item.Name(), runtime.GOARCH)]
+		vs = append(vs, vo)
+
+		arches := foreign[item.Name()]
+		sort.Strings(arches)
+		for _, goarch := range arches {
+			vs = append(vs, versionOut{
+				Version:   item.Name(),
+				Arch:      goarch,
+				InUse:     installed[installedDirName(item.Name(), goarch)],
+				Installed: true,
+				Support:   vo.Support,
+				Packages:  vo.Packages,
+			})
 		}
+	}
+	renderVersions(mode, vs, out)
+}
 
+// renderVersions 渲染go版本列表
+func renderVersions(mode uint8, vs []versionOut, out io.Writer) {
+	switch mode {
+	case jsonMode:
 		enc := json.NewEncoder(out)
 		enc.SetIndent("", "    ")
 		_ = enc.Encode(&vs)
 
+	case wideMode, tableMode:
+		renderVersionTable(mode, vs, out)
+
 	default:
-		for _, item := range items {
-			if inused, found := installed[item.Name()]; found {
-				if inused {
-					_, _ = color.New(color.FgGreen).Fprintf(out, "* %s\n", item.Name())
+		// 存在维护状态时，以对齐的第二列展示。
+		var width int
+		for _, vo := range vs {
+			if vo.Support != "" {
+				for _, vo := range vs {
+					if len(vo.dirName()) > width {
+						width = len(vo.dirName())
+					}
+				}
+				break
+			}
+		}
+
+		for _, vo := range vs {
+			line := vo.dirName()
+			if width > 0 && vo.Support != "" {
+				line = fmt.Sprintf("%-*s   %s", width, line, vo.Support)
+			}
+			if vo.Installed {
+				if vo.InUse {
+					_, _ = color.New(color.FgGreen).Fprintf(out, "* %s\n", line)
 				} else {
-					_, _ = color.New(color.FgGreen).Fprintf(out, "  %s\n", item.Name())
+					_, _ = color.New(color.FgGreen).Fprintf(out, "  %s\n", line)
 				}
 			} else {
-				_, _ = fmt.Fprintf(out, "  %s\n", item.Name())
+				_, _ = fmt.Fprintf(out, "  %s\n", line)
 			}
 		}
 	}
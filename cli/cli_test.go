@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"sort"
 	"strings"
 	"testing"
@@ -52,7 +53,7 @@ func Test_render(t *testing.T) {
 		}
 		sort.Sort(version.Collection(items))
 
-		render(textMode, map[string]bool{"1.8.1": true}, items, &got)
+		render(textMode, map[string]bool{"1.8.1": true}, nil, items, &got)
 		assert.Equal(t, "  1.7\n* 1.8.1\n  1.10beta2\n  1.19beta1\n  1.21rc4\n  1.21.0\n", got.String())
 	})
 
@@ -69,7 +70,7 @@ func Test_render(t *testing.T) {
 		sort.Sort(version.Collection(items))
 
 		installed := map[string]bool{"1.8.1": true}
-		render(jsonMode, installed, items, &actual)
+		render(jsonMode, installed, nil, items, &actual)
 
 		vs := make([]versionOut, 0, len(items))
 		for _, item := range items {
@@ -90,6 +91,18 @@ func Test_render(t *testing.T) {
 		_ = enc.Encode(&vs)
 		assert.Equal(t, expected.String(), actual.String())
 	})
+
+	t.Run("非本机硬件架构的已安装版本独立成行", func(t *testing.T) {
+		goarch := "386"
+		if runtime.GOARCH == goarch {
+			goarch = "amd64"
+		}
+		items := []*version.Version{version.MustNew("1.21.6"), version.MustNew("1.22.3")}
+
+		var got strings.Builder
+		render(textMode, map[string]bool{"1.21.6": false, "1.22.3-" + goarch: true}, nil, items, &got)
+		assert.Equal(t, "  1.21.6\n  1.22.3\n* 1.22.3-"+goarch+"\n", got.String())
+	})
 }
 
 func Test_wrapstring(t *testing.T) {
@@ -104,3 +117,143 @@ func Test_errstring(t *testing.T) {
 		assert.Equal(t, "[g] Hello world", errstring(errors.New("hello world")))
 	})
 }
+
+func Test_installedDirName(t *testing.T) {
+	t.Run("本机硬件架构", func(t *testing.T) {
+		assert.Equal(t, "1.22.3", installedDirName("1.22.3", runtime.GOARCH))
+		assert.Equal(t, "1.22.3", installedDirName("1.22.3", ""))
+	})
+
+	t.Run("其他硬件架构", func(t *testing.T) {
+		goarch := "386"
+		if runtime.GOARCH == goarch {
+			goarch = "amd64"
+		}
+		name := installedDirName("1.22.3", goarch)
+		assert.Equal(t, "1.22.3-"+goarch, name)
+
+		vname, arch := parseInstalledDirName(name)
+		assert.Equal(t, "1.22.3", vname)
+		assert.Equal(t, goarch, arch)
+	})
+
+	t.Run("解析不带架构后缀的目录名", func(t *testing.T) {
+		vname, arch := parseInstalledDirName("1.21rc4")
+		assert.Equal(t, "1.21rc4", vname)
+		assert.Equal(t, runtime.GOARCH, arch)
+	})
+
+	t.Run("后缀不是已知的硬件架构", func(t *testing.T) {
+		vname, arch := parseInstalledDirName("1.22.3-custom")
+		assert.Equal(t, "1.22.3-custom", vname)
+		assert.Equal(t, runtime.GOARCH, arch)
+	})
+}
+
+func Test_installed(t *testing.T) {
+	oldVersionsDir, oldGoroot := versionsDir, goroot
+	t.Cleanup(func() { versionsDir, goroot = oldVersionsDir, oldGoroot })
+
+	rootDir := t.TempDir()
+	versionsDir = filepath.Join(rootDir, "versions")
+	goroot = filepath.Join(rootDir, "go")
+	for _, name := range []string{"1.21.4", "1.22.3-386"} {
+		assert.Nil(t, os.MkdirAll(filepath.Join(versionsDir, name), 0755))
+	}
+	assert.Nil(t, os.Symlink(filepath.Join(versionsDir, "1.22.3-386"), goroot))
+
+	t.Run("以安装目录名为键", func(t *testing.T) {
+		assert.Equal(t, map[string]bool{"1.21.4": false, "1.22.3-386": true}, installed())
+	})
+}
+
+func Test_installedVersions(t *testing.T) {
+	oldVersionsDir := versionsDir
+	t.Cleanup(func() { versionsDir = oldVersionsDir })
+
+	t.Run("查询已安装的go版本", func(t *testing.T) {
+		versionsDir = filepath.Join(t.TempDir(), "versions")
+
+		for _, name := range []string{"1.22.3-386", "1.21.4", "1.22.3", "voidint"} {
+			_ = os.MkdirAll(filepath.Join(versionsDir, name), 0755)
+		}
+
+		items := installedVersions()
+		assert.Equal(t, 3, len(items))
+		assert.Equal(t, "1.21.4", items[0].Name())
+		assert.Equal(t, "1.22.3", items[1].Name())
+		assert.Equal(t, "1.22.3", items[2].Name())
+
+		dirs := make([]string, 0, len(items))
+		for _, item := range items {
+			dirs = append(dirs, installedDirName(item.Name(), item.Packages()[0].GOARCH))
+		}
+		assert.ElementsMatch(t, []string{"1.21.4", "1.22.3", "1.22.3-386"}, dirs)
+	})
+}
+
+func Test_installedTarget(t *testing.T) {
+	oldGhomeDir, oldVersionsDir := ghomeDir, versionsDir
+	t.Cleanup(func() { ghomeDir, versionsDir = oldGhomeDir, oldVersionsDir })
+
+	rootDir := t.TempDir()
+	ghomeDir = rootDir
+	versionsDir = filepath.Join(rootDir, "versions")
+
+	for _, name := range []string{"1.20.14", "1.21.4", "1.21.6", "1.22.3-386"} {
+		_ = os.MkdirAll(filepath.Join(versionsDir, name), 0755)
+	}
+
+	t.Run("已安装版本的目录名", func(t *testing.T) {
+		dirName, err := installedTarget("1.21.4", "")
+		assert.Nil(t, err)
+		assert.Equal(t, "1.21.4", dirName)
+
+		dirName, err = installedTarget("1.22.3", "386")
+		assert.Nil(t, err)
+		assert.Equal(t, "1.22.3-386", dirName)
+	})
+
+	t.Run("版本约束", func(t *testing.T) {
+		dirName, err := installedTarget("~1.21", "")
+		assert.Nil(t, err)
+		assert.Equal(t, "1.21.6", dirName)
+
+		_, err = installedTarget("~1.22", "")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("待卸载的版本", func(t *testing.T) {
+		dirNames, err := uninstallTargets("<1.21.6", "")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"1.20.14", "1.21.4"}, dirNames)
+	})
+}
+
+// newInstalled 返回与installedVersions结果形式相同的本机硬件架构的已安装版本
+func newInstalled(vname string) *version.Version {
+	return newInstalledArch(vname, runtime.GOARCH)
+}
+
+// newInstalledArch 返回与installedVersions结果形式相同的指定硬件架构的已安装版本
+func newInstalledArch(vname, goarch string) *version.Version {
+	return version.MustNew(vname, version.WithPackages([]*version.Package{{
+		FileName: installedDirName(vname, goarch),
+		Kind:     version.ArchiveKind,
+		GOOS:     runtime.GOOS,
+		GOARCH:   goarch,
+	}}))
+}
+
+// newRemote 返回仅包含本机平台压缩包的远程版本
+func newRemote(vname string) *version.Version {
+	return newRemoteOS(vname, runtime.GOOS)
+}
+
+// newRemoteOS 返回仅包含指定操作系统及本机硬件架构压缩包的远程版本
+func newRemoteOS(vname, goos string) *version.Version {
+	return version.MustNew(vname, version.WithPackages([]*version.Package{{
+		FileName: fmt.Sprintf("go%s.%s-%s.tar.gz", vname, goos, runtime.GOARCH),
+		Kind:     version.ArchiveKind,
+	}}))
+}
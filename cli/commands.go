@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/urfave/cli/v2"
+	"github.com/voidint/g/pkg/vulndb"
 )
 
 var (
@@ -12,16 +13,33 @@ var (
 			Name:      "ls",
 			Aliases:   []string{"l"},
 			Usage:     "List installed versions",
-			UsageText: "g ls",
+			UsageText: "g ls [version|constraint] [--unsupported] [-o text|json|wide|table] [--latest-per-minor] [--sort asc|desc] [--limit N]",
 			Flags: []cli.Flag{
 				&cli.StringFlag{
 					Name:    "output",
 					Aliases: []string{"o"},
-					Usage:   "Output format. One of: [text|json]",
+					Usage:   "Output format. One of: [text|json|wide|table]",
+				},
+				&cli.BoolFlag{
+					Name:  "latest-per-minor",
+					Usage: "Only list the newest patch release of each minor version",
+				},
+				&cli.StringFlag{
+					Name:  "sort",
+					Usage: "Sort order of versions. One of: [asc|desc]",
+					Value: sortAsc,
+				},
+				&cli.IntFlag{
+					Name:  "limit",
+					Usage: "Maximum number of versions to list, 0 means no limit",
+				},
+				&cli.BoolFlag{
+					Name:  "unsupported",
+					Usage: "Only list installed versions that no longer receive security fixes",
 				},
 			},
 			Before: func(ctx *cli.Context) error {
-				return validateLsFlag(ctx)
+				return validateListFlag(ctx)
 			},
 			Action: list,
 		},
@@ -29,44 +47,376 @@ var (
 			Name:      "ls-remote",
 			Aliases:   []string{"lr", "lsr"},
 			Usage:     "List remote versions available for install",
-			UsageText: "g ls-remote [stable|archived|unstable]",
+			UsageText: "g ls-remote [stable|archived|unstable|latest|latest-unstable|oldstable|supported|<constraint>] [--os <goos>] [--arch <goarch>] [--kind archive|source|installer] [-o text|json|wide|table] [--latest-per-minor] [--sort asc|desc] [--limit N]",
 			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "os",
+					Usage: "Only list versions with a package for the operating system, e.g. linux",
+				},
+				&cli.StringFlag{
+					Name:  "arch",
+					Usage: "Only list versions with a package for the architecture, e.g. amd64 or armv6l",
+				},
+				&cli.StringFlag{
+					Name:  "kind",
+					Usage: "Only list versions with a package of the kind. One of: [archive|source|installer]",
+				},
 				&cli.StringFlag{
 					Name:    "output",
 					Aliases: []string{"o"},
-					Usage:   "Output format. One of: [text|json]",
+					Usage:   "Output format. One of: [text|json|wide|table]",
+				},
+				&cli.BoolFlag{
+					Name:  "latest-per-minor",
+					Usage: "Only list the newest patch release of each minor version",
+				},
+				&cli.StringFlag{
+					Name:  "sort",
+					Usage: "Sort order of versions. One of: [asc|desc]",
+					Value: sortAsc,
+				},
+				&cli.IntFlag{
+					Name:  "limit",
+					Usage: "Maximum number of versions to list, 0 means no limit",
 				},
 			},
 			Before: func(ctx *cli.Context) error {
-				return validateLsFlag(ctx)
+				return validateListFlag(ctx)
 			},
 			Action: listRemote,
 		},
 		{
 			Name:      "use",
 			Usage:     "Switch to specified version",
-			UsageText: "g use <version>",
+			UsageText: "g use [--arch <goarch>] [--from-gomod | version|constraint|-]",
 			Action:    use,
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "from-gomod",
+					Usage: "Use the version required by the go and toolchain directives of go.work or go.mod",
+				},
+				&cli.StringFlag{
+					Name:  "arch",
+					Usage: "Architecture of the installed version, e.g. 386 (default: current GOARCH)",
+				},
+			},
 		},
 		{
 			Name:      "install",
 			Aliases:   []string{"i"},
 			Usage:     "Download and install a version",
-			UsageText: "g install <version>",
+			UsageText: "g install [--arch <goarch>] [--from-gomod | version]\n   g install --archive <path> [--checksum <hex>]",
 			Action:    install,
 			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "from-gomod",
+					Usage: "Install the version required by the go and toolchain directives of go.work or go.mod. An installed version that satisfies the go directive is preferred, otherwise its minimum version is installed",
+				},
+				&cli.BoolFlag{
+					Name:  "prerelease",
+					Usage: "Allow version constraints such as 1.23.x to match prereleases",
+				},
+				&cli.BoolFlag{
+					Name:  "wrappers",
+					Usage: "Create version-specific launchers such as go1.21.6 and go1.21 in ~/.g/bin",
+				},
+				&cli.StringFlag{
+					Name:  "archive",
+					Usage: "Install from a local archive file, e.g. go1.22.3.linux-amd64.tar.gz",
+				},
+				&cli.StringFlag{
+					Name:  "checksum",
+					Usage: "Expected SHA256 checksum of the archive specified by --archive",
+				},
+				&cli.StringFlag{
+					Name:  "arch",
+					Usage: "Architecture to install, installed side by side with the native one, e.g. 386 (default: current GOARCH)",
+				},
 				&cli.BoolFlag{
 					Name:    "nouse",
 					Aliases: []string{"n"},
 					Usage:   "Only install without using",
 				},
+				&cli.BoolFlag{
+					Name:    "yes",
+					Aliases: []string{"y"},
+					Usage:   "Automatically answer prompts with the default choice",
+				},
+				&cli.BoolFlag{
+					Name:  "no-checksum-ok",
+					Usage: "Continue installing when the package has no checksum",
+				},
+				&cli.StringFlag{
+					Name:  "package",
+					Usage: "File name of the package to install, e.g. go1.21.4.linux-armv6l.tar.gz",
+				},
+			},
+		},
+		{
+			Name:      "local",
+			Usage:     "Set or show the version of the current project",
+			UsageText: "g local [version]",
+			Action:    local,
+		},
+		{
+			Name:      "hook",
+			Usage:     "Print shell code that switches versions per project on directory change",
+			UsageText: "g hook bash|zsh|fish",
+			Description: `Add one of the following lines to the shell configuration file:
+	bash: eval "$(g hook bash)"   # ~/.bashrc
+	zsh:  eval "$(g hook zsh)"    # ~/.zshrc
+	fish: g hook fish | source    # ~/.config/fish/config.fish`,
+			Action: hook,
+		},
+		{
+			Name:      "hook-env",
+			Usage:     "Print environment changes for the project version of the current directory",
+			UsageText: "g hook-env bash|zsh|fish",
+			Action:    hookEnv,
+			Hidden:    true,
+		},
+		{
+			Name:      "shell",
+			Usage:     "Pin the current shell session to an installed version",
+			UsageText: "eval \"$(g shell [--arch <goarch>] <version>)\"\n   eval \"$(g shell --unset)\"",
+			Action:    shellEnv,
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "unset",
+					Usage: "Unpin the current shell session and fall back to the global version",
+				},
+				&cli.StringFlag{
+					Name:  "shell",
+					Usage: "Shell to print the code for, one of: [bash|zsh|fish] (default: inferred from $SHELL)",
+				},
+				&cli.StringFlag{
+					Name:  "arch",
+					Usage: "Architecture of the installed version, e.g. 386 (default: current GOARCH)",
+				},
+			},
+		},
+		{
+			Name:      "exec",
+			Usage:     "Run a command with a specified version without switching",
+			UsageText: "g exec [--install] [--arch <goarch>] <version> -- <command> [arguments...]",
+			Action:    execCmd,
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "install",
+					Usage: "Install the version first if it is not installed",
+				},
+				&cli.StringFlag{
+					Name:  "arch",
+					Usage: "Architecture of the version, e.g. 386 (default: current GOARCH)",
+				},
+				&cli.BoolFlag{
+					Name:    "yes",
+					Aliases: []string{"y"},
+					Usage:   "Automatically answer prompts with the default choice",
+				},
+				&cli.BoolFlag{
+					Name:  "no-checksum-ok",
+					Usage: "Continue installing when the package has no checksum",
+				},
+			},
+		},
+		{
+			Name:      "matrix",
+			Usage:     "Run a command under every version matching a constraint",
+			UsageText: "g matrix [--parallel <n>] [--all] [--report <file>] [-o json] <constraint> -- <command> [arguments...]",
+			Action:    matrix,
+			Flags: []cli.Flag{
+				&cli.IntFlag{
+					Name:    "parallel",
+					Aliases: []string{"p"},
+					Value:   1,
+					Usage:   "Number of versions to run concurrently",
+				},
+				&cli.BoolFlag{
+					Name:  "all",
+					Usage: "Run under every matching patch version instead of the latest of each minor version",
+				},
+				&cli.StringFlag{
+					Name:  "report",
+					Usage: "Write a JSON report to the specified file",
+				},
+				&cli.StringFlag{
+					Name:    "output",
+					Aliases: []string{"o"},
+					Usage:   "Output format of the summary. One of: [text|json]. With json, the report is written to stdout and everything else to stderr",
+				},
+				&cli.BoolFlag{
+					Name:    "yes",
+					Aliases: []string{"y"},
+					Usage:   "Automatically answer prompts with the default choice",
+				},
+				&cli.BoolFlag{
+					Name:  "no-checksum-ok",
+					Usage: "Continue installing when the package has no checksum",
+				},
+			},
+		},
+		{
+			Name:      "download",
+			Usage:     "Download a version for any platform without installing it",
+			UsageText: "g download [--os <goos>] [--arch <goarch>] [--dir <dir>] [--extract] <version>",
+			Action:    download,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "os",
+					Usage: "Target operating system, e.g. linux, darwin, windows (default: current GOOS)",
+				},
+				&cli.StringFlag{
+					Name:  "arch",
+					Usage: "Target architecture, e.g. amd64, arm64, 386 (default: current GOARCH)",
+				},
+				&cli.StringFlag{
+					Name:    "dir",
+					Aliases: []string{"d"},
+					Usage:   "Directory to save or extract the package into (default: current directory)",
+				},
+				&cli.BoolFlag{
+					Name:    "extract",
+					Aliases: []string{"x"},
+					Usage:   "Extract the package into the directory instead of keeping the archive",
+				},
+				&cli.BoolFlag{
+					Name:    "yes",
+					Aliases: []string{"y"},
+					Usage:   "Automatically answer prompts with the default choice",
+				},
+				&cli.BoolFlag{
+					Name:  "no-checksum-ok",
+					Usage: "Continue downloading when the package has no checksum",
+				},
+				&cli.StringFlag{
+					Name:  "package",
+					Usage: "File name of the package to download, e.g. go1.21.4.linux-armv6l.tar.gz",
+				},
 			},
 		},
 		{
 			Name:      "uninstall",
 			Usage:     "Uninstall a version",
-			UsageText: "g uninstall <version>",
+			UsageText: "g uninstall [--arch <goarch>] [--yes] <version|constraint>",
 			Action:    uninstall,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "arch",
+					Usage: "Architecture of the installed version, e.g. 386 (default: current GOARCH)",
+				},
+				&cli.BoolFlag{
+					Name:    "yes",
+					Aliases: []string{"y"},
+					Usage:   "Uninstall all matching versions without confirmation",
+				},
+			},
+		},
+		{
+			Name:      "upgrade",
+			Usage:     "Upgrade installed minor versions to their latest patch releases",
+			UsageText: "g upgrade [--prune] [--dry-run] [-o text|json] [constraint]",
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "prune",
+					Usage: "Remove the superseded patch releases after upgrading",
+				},
+				&cli.BoolFlag{
+					Name:  "dry-run",
+					Usage: "Show what would be upgraded without installing anything",
+				},
+				&cli.StringFlag{
+					Name:    "output",
+					Aliases: []string{"o"},
+					Usage:   "Output format. One of: [text|json]",
+				},
+				&cli.BoolFlag{
+					Name:    "yes",
+					Aliases: []string{"y"},
+					Usage:   "Automatically answer prompts with the default choice",
+				},
+				&cli.BoolFlag{
+					Name:  "no-checksum-ok",
+					Usage: "Continue installing when the package has no checksum",
+				},
+			},
+			Before: func(ctx *cli.Context) error {
+				return validateLsFlag(ctx)
+			},
+			Action: upgrade,
+		},
+		{
+			Name:      "outdated",
+			Usage:     "Report installed versions with newer patch releases or unsupported minor versions",
+			UsageText: "g outdated [-o text|json] [--fail-on outdated|unsupported]",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "output",
+					Aliases: []string{"o"},
+					Usage:   "Output format. One of: [text|json]",
+				},
+				&cli.StringFlag{
+					Name:  "fail-on",
+					Usage: "Exit with code 5 if any installed version is outdated or unsupported. One of: [outdated|unsupported]",
+				},
+			},
+			Before: func(ctx *cli.Context) error {
+				return validateLsFlag(ctx)
+			},
+			Action: outdated,
+		},
+		{
+			Name:      "audit",
+			Usage:     "Check installed versions against the Go vulnerability database",
+			UsageText: "g audit [version] [--db url|dir] [-o text|json]",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "db",
+					Usage:   "Vulnerability database URL or local directory (default: " + vulndb.DefaultSource + ")",
+					EnvVars: []string{vulndbEnv},
+				},
+				&cli.StringFlag{
+					Name:    "output",
+					Aliases: []string{"o"},
+					Usage:   "Output format. One of: [text|json]",
+				},
+			},
+			Before: func(ctx *cli.Context) error {
+				return validateLsFlag(ctx)
+			},
+			Action: audit,
+		},
+		{
+			Name:      "info",
+			Usage:     "Show the packages, release date and support status of a version",
+			UsageText: "g info <version> [-o text|json]",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "output",
+					Aliases: []string{"o"},
+					Usage:   "Output format. One of: [text|json]",
+				},
+			},
+			Before: func(ctx *cli.Context) error {
+				return validateLsFlag(ctx)
+			},
+			Action: info,
+		},
+		{
+			Name:      "changelog",
+			Usage:     "List the releases between two versions and their security fixes",
+			UsageText: "g changelog <from>..[to] [-o text|json]",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "output",
+					Aliases: []string{"o"},
+					Usage:   "Output format. One of: [text|json]",
+				},
+			},
+			Before: func(ctx *cli.Context) error {
+				return validateLsFlag(ctx)
+			},
+			Action: changelog,
 		},
 		{
 			Name:      "update",
@@ -81,12 +431,92 @@ var (
 			UsageText: "g clean",
 			Action:    clean,
 		},
+		{
+			Name:  "alias",
+			Usage: "Manage version aliases such as prod or legacy",
+			Subcommands: []*cli.Command{
+				{
+					Name:      "set",
+					Usage:     "Define an alias for a version or version constraint",
+					UsageText: "g alias set <name> <version>",
+					Action:    setAlias,
+				},
+				{
+					Name:      "ls",
+					Aliases:   []string{"list"},
+					Usage:     "List aliases and the installed versions they resolve to",
+					UsageText: "g alias ls",
+					Action:    listAliases,
+				},
+				{
+					Name:      "rm",
+					Aliases:   []string{"remove"},
+					Usage:     "Remove an alias",
+					UsageText: "g alias rm <name>",
+					Action:    removeAlias,
+				},
+			},
+		},
+		{
+			Name:      "history",
+			Usage:     "List past use, install and uninstall operations",
+			UsageText: "g history [-o text|json]",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "output",
+					Aliases: []string{"o"},
+					Usage:   "Output format. One of: [text|json]",
+				},
+			},
+			Before: func(ctx *cli.Context) error {
+				return validateLsFlag(ctx)
+			},
+			Action: history,
+		},
 		{
 			Name:      "env",
 			Usage:     "Show env variables of g",
 			UsageText: "g env",
 			Action:    showEnv,
 		},
+		{
+			Name:  "wrappers",
+			Usage: "Manage version-specific launchers such as go1.21.6 in ~/.g/bin",
+			Subcommands: []*cli.Command{
+				{
+					Name:      "sync",
+					Usage:     "Regenerate launchers for the installed versions and remove stale ones",
+					UsageText: "g wrappers sync",
+					Action:    syncWrappersCmd,
+				},
+			},
+		},
+		{
+			Name:  "shims",
+			Usage: "Manage the go and gofmt shims that resolve the version on every invocation",
+			Subcommands: []*cli.Command{
+				{
+					Name:      "install",
+					Usage:     "Create the go and gofmt shims in ~/.g/shims",
+					UsageText: "g shims install",
+					Action:    installShims,
+				},
+				{
+					Name:      "remove",
+					Usage:     "Remove the go and gofmt shims",
+					UsageText: "g shims remove",
+					Action:    removeShims,
+				},
+			},
+		},
+		{
+			Name:            "shim-exec",
+			Usage:           "Run a tool of the version resolved for the current directory",
+			UsageText:       "g shim-exec <tool> [arguments...]",
+			Hidden:          true,
+			SkipFlagParsing: true,
+			Action:          shimExec,
+		},
 		{
 			Name:  "self",
 			Usage: "Modify g itself",
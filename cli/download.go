@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/k0kubun/go-ansi"
+	"github.com/mholt/archiver/v3"
+	"github.com/urfave/cli/v2"
+	"github.com/voidint/g/version"
+)
+
+func download(ctx *cli.Context) (err error) {
+	vname := ctx.Args().First()
+	if vname == "" {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	goos, goarch := ctx.String("os"), ctx.String("arch")
+	if goos == "" {
+		goos = runtime.GOOS
+	}
+	if goarch == "" {
+		goarch = runtime.GOARCH
+	}
+
+	dir := ctx.String("dir")
+	if dir == "" {
+		if dir, err = os.Getwd(); err != nil {
+			return cli.Exit(errstring(err), 1)
+		}
+	}
+
+	// 解压的目标目录已存在时尽早退出，避免无谓的下载。
+	targetDir := filepath.Join(dir, "go")
+	if ctx.Bool("extract") {
+		if _, err := os.Stat(targetDir); err == nil {
+			return cli.Exit(fmt.Sprintf("[g] %q already exists.", targetDir), 1)
+		}
+	}
+
+	// 查找版本
+	items, err := remoteVersions()
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	writeSupportCache(supportedLines(items))
+
+	v, err := version.NewFinder(items,
+		version.WithFinderPackageKind(version.ArchiveKind),
+		version.WithFinderGoos(goos),
+		version.WithFinderGoarch(goarch),
+	).Find(vname)
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+
+	// 查找版本下目标平台的安装包
+	pkgs, err := v.FindPackages(version.ArchiveKind, goos, goarch)
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	pkg, err := selectPackage(ctx, pkgs)
+	if err != nil {
+		return err
+	}
+
+	checksumNotFound := pkg.Checksum == "" && pkg.ChecksumURL == ""
+	skipChecksum, err := confirmSkipChecksum(ctx, pkg)
+	if err != nil {
+		return err
+	}
+	if checksumNotFound && !skipChecksum {
+		return
+	}
+
+	if err = os.MkdirAll(dir, 0750); err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+
+	if !ctx.Bool("extract") {
+		filename := filepath.Join(dir, pkg.FileName)
+		if err = fetchPackage(&pkg, filename, skipChecksum, ansi.NewAnsiStdout()); err != nil {
+			return err
+		}
+		fmt.Printf("Saved %s\n", filename)
+		return nil
+	}
+
+	// 需要解压的安装包先缓存至下载目录，再解压至目标目录。
+	filename := filepath.Join(downloadsDir, pkg.FileName)
+	if err = fetchPackage(&pkg, filename, skipChecksum, ansi.NewAnsiStdout()); err != nil {
+		return err
+	}
+
+	if err = archiver.Unarchive(filename, dir); err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	fmt.Printf("Extracted go%s (%s/%s) to %s\n", v.Name(), goos, goarch, targetDir)
+	return nil
+}
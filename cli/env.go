@@ -12,6 +12,8 @@ var envNames = []string{
 	homeEnv,
 	mirrorEnv,
 	experimentalEnv,
+	versionEnv,
+	vulndbEnv,
 }
 
 func showEnv(ctx *cli.Context) (err error) {
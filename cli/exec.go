@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/urfave/cli/v2"
+	"github.com/voidint/g/pkg/errs"
+)
+
+func execCmd(ctx *cli.Context) (err error) {
+	args := ctx.Args().Slice()
+	if len(args) > 1 && args[1] == "--" {
+		args = append(args[:1], args[2:]...)
+	}
+	if len(args) < 2 {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+	vname, cmdArgs := args[0], args[1:]
+	vname, _ = expandAlias(vname)
+
+	dirName, err := resolveInstalled(vname, ctx.String("arch"))
+	if err != nil {
+		if !ctx.Bool("install") || !(errs.IsVersionNotFound(err) || errs.IsPackageNotFound(err)) {
+			return cli.Exit(wrapstring(fmt.Sprintf("the %q version does not exist, please install it first: %s", vname, err.Error())), 1)
+		}
+		if dirName, err = installRemote(ctx, vname, ctx.String("arch")); err != nil || dirName == "" {
+			return err
+		}
+	}
+
+	code, err := runWithVersion(filepath.Join(versionsDir, dirName), cmdArgs, os.Stdin, os.Stdout, os.Stderr)
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	if code != 0 {
+		return cli.Exit("", code)
+	}
+	return nil
+}
+
+// runWithVersion 以targetV作为GOROOT运行命令，返回命令的退出码。全局的goroot软链接保持不变。
+func runWithVersion(targetV string, args []string, stdin io.Reader, stdout, stderr io.Writer) (code int, err error) {
+	path := switchGorootPath(os.Getenv("PATH"), os.Getenv("GOROOT"), targetV)
+
+	// 在新的PATH中查找命令，以便'go'、'gofmt'等命令指向目标版本。
+	name := args[0]
+	if filepath.Base(name) == name {
+		if name, err = lookPath(name, path); err != nil {
+			return 0, err
+		}
+	}
+
+	cmd := exec.Command(name, args[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = stdin, stdout, stderr
+	cmd.Env = versionEnviron(targetV, path)
+
+	if err = cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode(), nil
+		}
+		return 0, err
+	}
+	return 0, nil
+}
+
+// versionEnviron 返回以targetV作为GOROOT运行命令所需的环境变量
+func versionEnviron(targetV, path string) []string {
+	return append(os.Environ(),
+		"GOROOT="+targetV,
+		"PATH="+path,
+		versionEnv+"="+filepath.Base(targetV),
+	)
+}
+
+// lookPath 在指定的PATH中查找可执行文件。不修改当前进程的PATH，以便并发调用。
+func lookPath(file, path string) (string, error) {
+	for _, dir := range filepath.SplitList(path) {
+		if dir == "" {
+			continue
+		}
+		if name, err := exec.LookPath(filepath.Join(dir, file)); err == nil {
+			return name, nil
+		}
+	}
+	return "", &exec.Error{Name: file, Err: exec.ErrNotFound}
+}
@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	historyUse       = "use"
+	historyInstall   = "install"
+	historyUninstall = "uninstall"
+)
+
+// historyRecord 版本操作记录
+type historyRecord struct {
+	Time     time.Time `json:"time"`
+	Action   string    `json:"action"`
+	Version  string    `json:"version"`
+	Previous string    `json:"previous,omitempty"` // 切换前使用的版本，仅用于use操作。
+}
+
+// historyFile 返回记录版本操作历史的文件路径，文件中每行为一条JSON格式的记录。
+func historyFile() string {
+	return filepath.Join(ghomeDir, "history")
+}
+
+// appendHistory 追加一条版本操作记录。历史记录不影响操作本身，因此忽略写入错误。
+func appendHistory(action, vname, previous string) {
+	data, err := json.Marshal(historyRecord{
+		Time:     time.Now(),
+		Action:   action,
+		Version:  vname,
+		Previous: previous,
+	})
+	if err != nil {
+		return
+	}
+
+	f, err := os.OpenFile(historyFile(), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	_, _ = f.Write(append(data, '\n'))
+}
+
+// readHistory 按时间先后返回版本操作记录，忽略无法解析的行。
+func readHistory(filename string) (records []historyRecord, err error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record historyRecord
+		if err = json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
+
+// previousVersion 返回最近一次切换前使用的版本
+func previousVersion(records []historyRecord) (vname string, err error) {
+	for i := len(records) - 1; i >= 0; i-- {
+		if records[i].Action != historyUse || records[i].Previous == records[i].Version {
+			continue // 忽略未切换版本的记录
+		}
+		if records[i].Previous == "" {
+			break
+		}
+		return records[i].Previous, nil
+	}
+	return "", errors.New("no previous version to switch back to")
+}
+
+func history(ctx *cli.Context) (err error) {
+	records, err := readHistory(historyFile())
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+
+	switch ctx.String("output") {
+	case "json":
+		if records == nil {
+			records = []historyRecord{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "    ")
+		_ = enc.Encode(records)
+	default:
+		if len(records) == 0 {
+			fmt.Printf("No history yet\n\n")
+			return nil
+		}
+		renderHistory(records, os.Stdout)
+	}
+	return nil
+}
+
+func renderHistory(records []historyRecord, out io.Writer) {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	for _, record := range records {
+		line := fmt.Sprintf("%s\t%s\t%s", record.Time.Local().Format("2006-01-02 15:04:05"), record.Action, record.Version)
+		if record.Previous != "" {
+			line += fmt.Sprintf(" (previously %s)", record.Previous)
+		}
+		_, _ = fmt.Fprintln(w, line)
+	}
+	_ = w.Flush()
+}
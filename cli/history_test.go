@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_previousVersion(t *testing.T) {
+	t.Run("切换回上一个版本", func(t *testing.T) {
+		records := []historyRecord{
+			{Action: historyUse, Version: "1.21.6", Previous: "1.20.14"},
+			{Action: historyInstall, Version: "1.22rc1"},
+			{Action: historyUse, Version: "1.22rc1", Previous: "1.21.6"},
+			{Action: historyUninstall, Version: "1.20.14"},
+			{Action: historyUse, Version: "1.22rc1", Previous: "1.22rc1"},
+		}
+		vname, err := previousVersion(records)
+		assert.Nil(t, err)
+		assert.Equal(t, "1.21.6", vname)
+	})
+
+	t.Run("首次切换前无可用版本", func(t *testing.T) {
+		_, err := previousVersion([]historyRecord{{Action: historyInstall, Version: "1.21.6"}, {Action: historyUse, Version: "1.21.6"}})
+		assert.NotNil(t, err)
+
+		_, err = previousVersion(nil)
+		assert.NotNil(t, err)
+	})
+}
+
+func Test_readHistory(t *testing.T) {
+	oldGhomeDir := ghomeDir
+	t.Cleanup(func() { ghomeDir = oldGhomeDir })
+
+	t.Run("读取历史记录", func(t *testing.T) {
+		ghomeDir = t.TempDir()
+		appendHistory(historyInstall, "1.21.6", "")
+		appendHistory(historyUse, "1.21.6", "1.20.14")
+
+		records, err := readHistory(historyFile())
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(records))
+		assert.Equal(t, historyUse, records[1].Action)
+		assert.Equal(t, "1.20.14", records[1].Previous)
+	})
+
+	t.Run("历史记录文件不存在", func(t *testing.T) {
+		records, err := readHistory(filepath.Join(t.TempDir(), "history"))
+		assert.Nil(t, err)
+		assert.Empty(t, records)
+	})
+}
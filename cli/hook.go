@@ -0,0 +1,167 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	bashShell = "bash"
+	zshShell  = "zsh"
+	fishShell = "fish"
+)
+
+// hookGorootEnv 记录由shell钩子为当前会话设置的GOROOT，以便切换目录时将其从PATH中移除。
+const hookGorootEnv = "G_HOOK_GOROOT"
+
+var hookScripts = map[string]string{
+	bashShell: `_g_hook() {
+  local previous_exit_status=$?
+  if [[ "${_G_HOOK_PWD:-}" != "$PWD" ]]; then
+    _G_HOOK_PWD="$PWD"
+    eval "$(%[1]s hook-env bash)"
+  fi
+  return $previous_exit_status
+}
+if [[ ";${PROMPT_COMMAND[*]:-};" != *";_g_hook;"* ]]; then
+  PROMPT_COMMAND="_g_hook${PROMPT_COMMAND:+;$PROMPT_COMMAND}"
+fi
+`,
+	zshShell: `_g_hook() {
+  eval "$(%[1]s hook-env zsh)"
+}
+typeset -ag chpwd_functions
+if (( ! ${chpwd_functions[(I)_g_hook]} )); then
+  chpwd_functions=(_g_hook $chpwd_functions)
+fi
+_g_hook
+`,
+	fishShell: `function _g_hook --on-variable PWD
+    %[1]s hook-env fish | source
+end
+_g_hook
+`,
+}
+
+func validateShellArg(ctx *cli.Context) (shell string, err error) {
+	shell = ctx.Args().First()
+	if _, ok := hookScripts[shell]; !ok {
+		return "", cli.Exit(errstring(fmt.Errorf("unsupported shell %q, supported shells are: [bash|zsh|fish]", shell)), 1)
+	}
+	return shell, nil
+}
+
+func hook(ctx *cli.Context) (err error) {
+	if ctx.Args().First() == "" {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+	shell, err := validateShellArg(ctx)
+	if err != nil {
+		return err
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	fmt.Printf(hookScripts[shell], quoteShell(shell, exe))
+	return nil
+}
+
+// hookEnv 输出切换至当前项目所需版本的shell代码，仅作用于当前shell会话，不改变全局的goroot软链接。
+func hookEnv(ctx *cli.Context) (err error) {
+	shell, err := validateShellArg(ctx)
+	if err != nil {
+		return err
+	}
+
+	if os.Getenv(versionEnv) != "" {
+		return nil // 当前会话已通过'g shell'固定了版本
+	}
+
+	prevRoot := os.Getenv(hookGorootEnv)
+
+	filename, vname, err := projectVersion()
+	if err != nil || vname == "" {
+		if prevRoot != "" {
+			// 离开项目目录，恢复为全局版本。
+			printSessionEnv(os.Stdout, shell, prevRoot, goroot)
+			fmt.Print(unsetEnv(shell, hookGorootEnv))
+		}
+		return nil
+	}
+
+	dirName, err := resolveInstalled(vname, "")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "g: the %q version required by %s is not installed\n", vname, filename)
+		if prevRoot != "" {
+			printSessionEnv(os.Stdout, shell, prevRoot, goroot)
+			fmt.Print(unsetEnv(shell, hookGorootEnv))
+		}
+		return nil
+	}
+
+	targetV := filepath.Join(versionsDir, dirName)
+	if targetV == prevRoot {
+		return nil
+	}
+	printSessionEnv(os.Stdout, shell, prevRoot, targetV)
+	fmt.Print(exportEnv(shell, hookGorootEnv, targetV))
+	return nil
+}
+
+// printSessionEnv 输出将当前会话的GOROOT由oldRoot切换至newRoot的shell代码
+func printSessionEnv(w io.Writer, shell, oldRoot, newRoot string) {
+	_, _ = fmt.Fprint(w, exportEnv(shell, "GOROOT", newRoot))
+	_, _ = fmt.Fprint(w, exportEnv(shell, "PATH", switchGorootPath(os.Getenv("PATH"), oldRoot, newRoot)))
+}
+
+// switchGorootPath 从PATH中移除oldRoot下的bin目录，并将newRoot下的bin目录置于PATH首位。
+func switchGorootPath(path, oldRoot, newRoot string) string {
+	newBin := filepath.Join(newRoot, "bin")
+	items := []string{newBin}
+	for _, item := range filepath.SplitList(path) {
+		if item == "" || item == newBin || (oldRoot != "" && item == filepath.Join(oldRoot, "bin")) {
+			continue
+		}
+		items = append(items, item)
+	}
+	return strings.Join(items, string(os.PathListSeparator))
+}
+
+func exportEnv(shell, name, value string) string {
+	if shell == fishShell {
+		if name == "PATH" { // fish中的PATH为列表
+			return fmt.Sprintf("set -gx PATH %s;\n", strings.Join(quoteShellAll(shell, filepath.SplitList(value)), " "))
+		}
+		return fmt.Sprintf("set -gx %s %s;\n", name, quoteShell(shell, value))
+	}
+	return fmt.Sprintf("export %s=%s;\n", name, quoteShell(shell, value))
+}
+
+func unsetEnv(shell, name string) string {
+	if shell == fishShell {
+		return fmt.Sprintf("set -e %s;\n", name)
+	}
+	return fmt.Sprintf("unset %s;\n", name)
+}
+
+// quoteShell 返回适用于目标shell的单引号字符串
+func quoteShell(shell, s string) string {
+	if shell == fishShell {
+		return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func quoteShellAll(shell string, items []string) []string {
+	quoted := make([]string, 0, len(items))
+	for i := range items {
+		quoted = append(quoted, quoteShell(shell, items[i]))
+	}
+	return quoted
+}
@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_switchGorootPath(t *testing.T) {
+	sep := string(os.PathListSeparator)
+	join := func(items ...string) string { return strings.Join(items, sep) }
+
+	t.Run("切换至项目版本", func(t *testing.T) {
+		got := switchGorootPath(join("/home/g/.g/go/bin", "/usr/bin"), "", "/home/g/.g/versions/1.21.4")
+		assert.Equal(t, join("/home/g/.g/versions/1.21.4/bin", "/home/g/.g/go/bin", "/usr/bin"), got)
+	})
+
+	t.Run("在项目版本间切换", func(t *testing.T) {
+		got := switchGorootPath(join("/home/g/.g/versions/1.21.4/bin", "/home/g/.g/go/bin", "/usr/bin"), "/home/g/.g/versions/1.21.4", "/home/g/.g/versions/1.22.3")
+		assert.Equal(t, join("/home/g/.g/versions/1.22.3/bin", "/home/g/.g/go/bin", "/usr/bin"), got)
+	})
+
+	t.Run("恢复为全局版本", func(t *testing.T) {
+		got := switchGorootPath(join("/home/g/.g/versions/1.22.3/bin", "/home/g/.g/go/bin", "/usr/bin"), "/home/g/.g/versions/1.22.3", "/home/g/.g/go")
+		assert.Equal(t, join("/home/g/.g/go/bin", "/usr/bin"), got)
+	})
+}
+
+func Test_exportEnv(t *testing.T) {
+	assert.Equal(t, "export GOROOT='/home/g/.g/versions/1.21.4';\n", exportEnv(bashShell, "GOROOT", "/home/g/.g/versions/1.21.4"))
+	assert.Equal(t, "export GOROOT='/it'\\''s';\n", exportEnv(zshShell, "GOROOT", "/it's"))
+	assert.Equal(t, "set -gx GOROOT '/it\\'s';\n", exportEnv(fishShell, "GOROOT", "/it's"))
+	assert.Equal(t, "unset G_HOOK_GOROOT;\n", unsetEnv(bashShell, hookGorootEnv))
+	assert.Equal(t, "set -e G_HOOK_GOROOT;\n", unsetEnv(fishShell, hookGorootEnv))
+}
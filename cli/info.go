@@ -0,0 +1,208 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v2"
+	"github.com/voidint/g/pkg/release"
+	"github.com/voidint/g/version"
+)
+
+// infoOut 版本详情
+type infoOut struct {
+	Version   string            `json:"version"`
+	Released  string            `json:"released,omitempty"` // 发布日期，未知时为空。
+	Support   string            `json:"support,omitempty"`  // 维护状态，未知时为空。
+	Installed bool              `json:"installed"`
+	Arches    []string          `json:"arches,omitempty"` // 已安装的硬件架构，含同一版本并存安装的其他硬件架构。
+	InUse     bool              `json:"inUse"`
+	Notes     string            `json:"notes,omitempty"`
+	Packages  []version.Package `json:"packages"`
+}
+
+func info(ctx *cli.Context) (err error) {
+	vname := ctx.Args().First()
+	if vname == "" {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+	target, _ := expandAlias(vname)
+	if err = validateVersionArg(target); err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+
+	remote, err := remoteVersions()
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	v, err := version.NewFinder(remote,
+		version.WithFinderPackageKind(""),
+		version.WithFinderGoos(""),
+		version.WithFinderGoarch(""),
+	).Find(target)
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+
+	supported := supportedLines(remote)
+	writeSupportCache(supported)
+
+	out := infoOut{
+		Version:  v.Name(),
+		Support:  supportStatus(v, supported),
+		Packages: v.Packages(),
+	}
+	out.Arches, out.InUse = installedArches(v.Name())
+	out.Installed = len(out.Arches) > 0
+	// 发布日期及说明仅作参考，获取失败时不影响版本详情的展示。
+	if releases, err := release.Fetch(release.DefaultURL); err == nil {
+		if r := release.Find(releases, v.Name()); r != nil {
+			out.Released = r.Date
+			out.Notes = r.Notes
+		}
+	}
+
+	switch ctx.String("output") {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "    ")
+		_ = enc.Encode(out)
+	default:
+		renderInfo(out, os.Stdout)
+	}
+	return nil
+}
+
+// installedArches 返回指定版本已安装的硬件架构，以及其中是否有正在使用的安装。
+func installedArches(vname string) (arches []string, inUse bool) {
+	inused := inuse(goroot)
+	for _, item := range installedVersions() {
+		if item.Name() != vname {
+			continue
+		}
+		goarch := installedArch(item)
+		arches = append(arches, goarch)
+		inUse = inUse || installedDirName(vname, goarch) == inused
+	}
+	return arches, inUse
+}
+
+func renderInfo(out infoOut, w io.Writer) {
+	orUnknown := func(s string) string {
+		if s == "" {
+			return "unknown"
+		}
+		return s
+	}
+	installed := "no"
+	if out.Installed {
+		details := append([]string(nil), out.Arches...)
+		if out.InUse {
+			details = append(details, "in use")
+		}
+		installed = "yes"
+		if len(details) > 0 {
+			installed += " (" + strings.Join(details, ", ") + ")"
+		}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "Version:\t%s\n", out.Version)
+	_, _ = fmt.Fprintf(tw, "Released:\t%s\n", orUnknown(out.Released))
+	_, _ = fmt.Fprintf(tw, "Support:\t%s\n", orUnknown(out.Support))
+	_, _ = fmt.Fprintf(tw, "Installed:\t%s\n", installed)
+	if out.Notes != "" {
+		_, _ = fmt.Fprintf(tw, "Notes:\t%s\n", out.Notes)
+	}
+	_ = tw.Flush()
+
+	if len(out.Packages) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintln(w)
+	tw = tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "FILENAME\tKIND\tOS\tARCH\tSIZE\tCHECKSUM")
+	for _, pkg := range out.Packages {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", pkg.FileName, pkg.Kind, pkg.OS, pkg.Arch, pkg.Size, pkg.Checksum)
+	}
+	_ = tw.Flush()
+}
+
+// changelogRangeSep 发布范围参数中起止版本的分隔符，如'1.21.3..1.22.1'。
+const changelogRangeSep = ".."
+
+// parseChangelogRange 解析形如'<from>..<to>'的发布范围，to可以省略，表示直至最新发布。
+func parseChangelogRange(arg string) (from, to string, err error) {
+	idx := strings.Index(arg, changelogRangeSep)
+	if idx <= 0 {
+		return "", "", fmt.Errorf("invalid release range %q, expected <from>..<to>", arg)
+	}
+	from, to = arg[:idx], arg[idx+len(changelogRangeSep):]
+	if _, err = version.New(from); err != nil {
+		return "", "", err
+	}
+	if to == "" {
+		return from, "", nil
+	}
+	if _, err = version.New(to); err != nil {
+		return "", "", err
+	}
+	if release.Less(to, from) {
+		return "", "", errors.New("the start of the release range must not be newer than its end")
+	}
+	return from, to, nil
+}
+
+func changelog(ctx *cli.Context) (err error) {
+	arg := ctx.Args().First()
+	if arg == "" {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+	from, to, err := parseChangelogRange(arg)
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+
+	releases, err := release.Fetch(release.DefaultURL)
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	items := release.Between(releases, from, to)
+
+	switch ctx.String("output") {
+	case "json":
+		if items == nil {
+			items = []*release.Release{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "    ")
+		_ = enc.Encode(items)
+	default:
+		if len(items) == 0 {
+			fmt.Printf("No release found in %s\n\n", arg)
+			return nil
+		}
+		renderChangelog(items, os.Stdout)
+	}
+	return nil
+}
+
+func renderChangelog(items []*release.Release, w io.Writer) {
+	var security int
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	for _, r := range items {
+		kind := ""
+		if r.Security {
+			kind = "security"
+			security++
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.Version, r.Date, kind, r.Notes)
+	}
+	_ = tw.Flush()
+	_, _ = fmt.Fprintf(w, "\n%d release(s), %d with security fixes\n", len(items), security)
+}
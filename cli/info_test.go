@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/pkg/release"
+	"github.com/voidint/g/version"
+)
+
+func Test_parseChangelogRange(t *testing.T) {
+	tests := []struct {
+		arg     string
+		from    string
+		to      string
+		wantErr bool
+	}{
+		{arg: "1.21.3..1.22.1", from: "1.21.3", to: "1.22.1"},
+		{arg: "1.21.3..", from: "1.21.3"},
+		{arg: "1.21", wantErr: true},
+		{arg: "..1.22.1", wantErr: true},
+		{arg: "1.21.3..hello", wantErr: true},
+		{arg: "1.22.1..1.21.3", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			from, to, err := parseChangelogRange(tt.arg)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.from, from)
+			assert.Equal(t, tt.to, to)
+		})
+	}
+}
+
+func Test_renderInfo(t *testing.T) {
+	t.Run("渲染版本详情", func(t *testing.T) {
+		var got strings.Builder
+		renderInfo(infoOut{
+			Version:   "1.22.1",
+			Released:  "2024-03-05",
+			Support:   supportSupported,
+			Installed: true,
+			Packages: []version.Package{{
+				FileName: "go1.22.1.linux-amd64.tar.gz",
+				Kind:     version.ArchiveKind,
+				OS:       "Linux",
+				Arch:     "x86-64",
+				Size:     "66MB",
+				Checksum: "aab8e15785c997ae20f9c88422ee35d962c4562212bb0f879d052a35c8307c7f",
+			}},
+		}, &got)
+		assert.Equal(t, `Version:     1.22.1
+Released:    2024-03-05
+Support:     supported
+Installed:   yes
+
+FILENAME                      KIND      OS      ARCH     SIZE   CHECKSUM
+go1.22.1.linux-amd64.tar.gz   Archive   Linux   x86-64   66MB   aab8e15785c997ae20f9c88422ee35d962c4562212bb0f879d052a35c8307c7f
+`, got.String())
+	})
+
+	t.Run("发布日期未知", func(t *testing.T) {
+		var got strings.Builder
+		renderInfo(infoOut{Version: "1.23rc1"}, &got)
+		assert.Equal(t, "Version:     1.23rc1\nReleased:    unknown\nSupport:     unknown\nInstalled:   no\n", got.String())
+	})
+}
+
+func Test_installedArches(t *testing.T) {
+	oldVersionsDir, oldGoroot := versionsDir, goroot
+	t.Cleanup(func() { versionsDir, goroot = oldVersionsDir, oldGoroot })
+
+	rootDir := t.TempDir()
+	versionsDir = filepath.Join(rootDir, "versions")
+	goroot = filepath.Join(rootDir, "go")
+	assert.Nil(t, os.MkdirAll(filepath.Join(versionsDir, "1.22.3-386"), 0755))
+	assert.Nil(t, os.Symlink(filepath.Join(versionsDir, "1.22.3-386"), goroot))
+
+	t.Run("仅安装了其他硬件架构", func(t *testing.T) {
+		arches, inUse := installedArches("1.22.3")
+		assert.Equal(t, []string{"386"}, arches)
+		assert.True(t, inUse)
+
+		var got strings.Builder
+		renderInfo(infoOut{Version: "1.22.3", Installed: true, Arches: arches, InUse: inUse}, &got)
+		assert.Contains(t, got.String(), "Installed:   yes (386, in use)\n")
+	})
+
+	t.Run("版本未安装", func(t *testing.T) {
+		arches, inUse := installedArches("1.21.4")
+		assert.Empty(t, arches)
+		assert.False(t, inUse)
+	})
+}
+
+func Test_renderChangelog(t *testing.T) {
+	var got strings.Builder
+	renderChangelog([]*release.Release{
+		{Version: "1.21.2", Date: "2023-10-05", Security: true, Notes: "includes one security fix to the cmd/go package."},
+		{Version: "1.21.3", Date: "2023-10-10", Notes: "includes bug fixes to the runtime."},
+	}, &got)
+	assert.Equal(t, `1.21.2   2023-10-05   security   includes one security fix to the cmd/go package.
+1.21.3   2023-10-10              includes bug fixes to the runtime.
+
+2 release(s), 1 with security fixes
+`, got.String())
+}
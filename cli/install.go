@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -11,152 +12,284 @@ import (
 	ct "github.com/daviddengcn/go-colortext"
 	"github.com/dixonwille/wlog/v3"
 	"github.com/dixonwille/wmenu/v5"
+	"github.com/k0kubun/go-ansi"
 	"github.com/mholt/archiver/v3"
 	"github.com/urfave/cli/v2"
-	"github.com/voidint/g/collector"
 	"github.com/voidint/g/version"
 )
 
 func install(ctx *cli.Context) (err error) {
+	if ctx.String("archive") != "" {
+		return installFromArchive(ctx)
+	}
+
 	vname := ctx.Args().First()
-	if vname == "" {
-		return cli.ShowSubcommandHelp(ctx)
+	if ctx.Bool("from-gomod") {
+		if vname != "" {
+			return cli.Exit(wrapstring("the --from-gomod flag cannot be used together with a version argument"), 1)
+		}
+		return installFromGomod(ctx)
+	} else if vname == "" {
+		// 未指定版本时安装项目版本文件中指定的版本
+		if _, vname, err = projectVersion(); err != nil {
+			return cli.Exit(errstring(err), 1)
+		}
+		if vname == "" {
+			return cli.ShowSubcommandHelp(ctx)
+		}
+	} else {
+		vname, _ = expandAlias(vname)
 	}
 
-	// 查找版本
-	c, err := collector.NewCollector(strings.Split(os.Getenv(mirrorEnv), mirrorSep)...)
+	dirName, err := installRemote(ctx, vname, ctx.String("arch"))
+	if err != nil || dirName == "" {
+		return err
+	}
+	return useInstalled(ctx, dirName)
+}
+
+// installFromGomod 安装go.mod（go.work）所要求的版本。
+// 已安装满足要求的版本时直接使用（存在多个时选用最高的版本），否则安装满足要求的最低版本，
+// 如'go 1.21'将安装1.21.0而非最新的版本，与go命令自动切换工具链的行为一致。
+func installFromGomod(ctx *cli.Context) (err error) {
+	filename, vname, err := gomodVersion()
 	if err != nil {
 		return cli.Exit(errstring(err), 1)
 	}
-	items, err := c.AllVersions()
+	if dirName, err := resolveInstalled(vname, ctx.String("arch")); err == nil {
+		fmt.Printf("Installed go%s satisfies %q required by %s\n", dirName, vname, filename)
+		return useInstalled(ctx, dirName)
+	}
+
+	dirName, err := installRemoteVersion(ctx, vname, ctx.String("arch"), true)
+	if err != nil || dirName == "" {
+		return err
+	}
+	return useInstalled(ctx, dirName)
+}
+
+// installRemote 从镜像站点下载并安装满足条件的最高版本，返回其安装目录名。用户放弃安装时返回空字符串。
+func installRemote(ctx *cli.Context, vname, goarch string) (dirName string, err error) {
+	return installRemoteVersion(ctx, vname, goarch, false)
+}
+
+// installRemoteVersion 从镜像站点下载并安装满足条件的版本，minimum为true时安装满足条件的最低版本，否则安装最高版本。
+func installRemoteVersion(ctx *cli.Context, vname, goarch string, minimum bool) (dirName string, err error) {
+	if goarch == "" {
+		goarch = runtime.GOARCH
+	}
+
+	// 查找版本
+	items, err := remoteVersions()
 	if err != nil {
-		return cli.Exit(errstring(err), 1)
+		return "", cli.Exit(errstring(err), 1)
 	}
+	writeSupportCache(supportedLines(items))
 
-	v, err := version.NewFinder(items,
+	fdr := version.NewFinder(items,
 		version.WithFinderPackageKind(version.ArchiveKind),
 		version.WithFinderGoos(runtime.GOOS),
-		version.WithFinderGoarch(runtime.GOARCH),
-	).Find(vname)
+		version.WithFinderGoarch(goarch),
+		version.WithFinderPrerelease(ctx.Bool("prerelease")),
+	)
+	var v *version.Version
+	if minimum {
+		var vs []*version.Version
+		if vs, err = fdr.FindAll(vname); err == nil {
+			v = vs[0]
+		}
+	} else {
+		v, err = fdr.Find(vname)
+	}
 	if err != nil {
-		return cli.Exit(errstring(err), 1)
+		return "", cli.Exit(errstring(err), 1)
 	}
+	return installVersion(ctx, v, goarch, ansi.NewAnsiStdout())
+}
 
-	vname = v.Name()
-	targetV := filepath.Join(versionsDir, vname)
+// installVersion 下载并安装版本v下当前平台的安装包，下载进度等信息输出至out。
+func installVersion(ctx *cli.Context, v *version.Version, goarch string, out io.Writer) (dirName string, err error) {
+	dirName = installedDirName(v.Name(), goarch)
+	targetV := filepath.Join(versionsDir, dirName)
 
 	// 检查版本是否已经安装
 	if finfo, err := os.Stat(targetV); err == nil && finfo.IsDir() {
-		return cli.Exit(fmt.Sprintf("[g] %q version has been installed.", vname), 1)
+		return "", cli.Exit(fmt.Sprintf("[g] %q version has been installed.", dirName), 1)
 	}
 
 	// 查找版本下当前平台的安装包
-	pkgs, err := v.FindPackages(version.ArchiveKind, runtime.GOOS, runtime.GOARCH)
+	pkgs, err := v.FindPackages(version.ArchiveKind, runtime.GOOS, goarch)
+	if err != nil {
+		return "", cli.Exit(errstring(err), 1)
+	}
+	pkg, err := selectPackage(ctx, pkgs)
+	if err != nil {
+		return "", err
+	}
+
+	checksumNotFound := pkg.Checksum == "" && pkg.ChecksumURL == ""
+	skipChecksum, err := confirmSkipChecksum(ctx, pkg)
 	if err != nil {
+		return "", err
+	}
+	if checksumNotFound && !skipChecksum {
+		return "", nil
+	}
+
+	filename := filepath.Join(downloadsDir, pkg.FileName)
+	if err = fetchPackage(&pkg, filename, skipChecksum, out); err != nil {
+		return "", err
+	}
+
+	if err = extractArchive(filename, dirName); err != nil {
+		return "", err
+	}
+	appendHistory(historyInstall, dirName, "")
+	return dirName, nil
+}
+
+// extractArchive 将安装包解压至版本目录
+func extractArchive(filename, dirName string) (err error) {
+	// 删除可能存在的历史垃圾文件
+	_ = os.RemoveAll(filepath.Join(versionsDir, "go"))
+
+	// 解压安装包
+	if err = archiver.Unarchive(filename, versionsDir); err != nil {
 		return cli.Exit(errstring(err), 1)
 	}
-	var pkg version.Package
-	if len(pkgs) > 1 {
-		menu := wmenu.NewMenu("Please select the package you want to install.")
-		menu.AddColor(
-			wlog.Color{Code: ct.Green},
-			wlog.Color{Code: ct.Yellow},
-			wlog.Color{Code: ct.Magenta},
-			wlog.Color{Code: ct.Yellow},
-		)
-		menu.Action(func(opts []wmenu.Opt) error {
-			pkg = opts[0].Value.(version.Package)
-			return nil
-		})
-		for i := range pkgs {
-			if i == 0 {
-				menu.Option(pkgs[i].FileName, pkgs[i], true, nil)
-			} else {
-				menu.Option(" "+pkgs[i].FileName, pkgs[i], false, nil)
-			}
-		}
-		if err = menu.Run(); err != nil {
-			return cli.Exit(errstring(err), 1)
-		}
-	} else {
-		pkg = pkgs[0]
+	// 目录重命名
+	if err = os.Rename(filepath.Join(versionsDir, "go"), filepath.Join(versionsDir, dirName)); err != nil {
+		return cli.Exit(errstring(err), 1)
 	}
+	return nil
+}
 
-	var checksumNotFound, skipChecksum bool
-	if pkg.Checksum == "" && pkg.ChecksumURL == "" {
-		checksumNotFound = true
-		menu := wmenu.NewMenu("Checksum file not found, do you want to continue?")
-		menu.IsYesNo(wmenu.DefN)
-		menu.Action(func(opts []wmenu.Opt) error {
-			skipChecksum = opts[0].Value.(string) == "yes"
-			return nil
-		})
-		if err = menu.Run(); err != nil {
+// useInstalled 切换至刚安装的版本，指定了--nouse时不切换。指定了--wrappers时同步更新版本启动器。
+func useInstalled(ctx *cli.Context, dirName string) (err error) {
+	if ctx.Bool("wrappers") {
+		if err = syncWrappers(wrappersDir()); err != nil {
 			return cli.Exit(errstring(err), 1)
 		}
 	}
-	if checksumNotFound && !skipChecksum {
-		return
+	if ctx.Bool("nouse") {
+		return nil
 	}
 
-	var ext string
-	if runtime.GOOS == "windows" {
-		ext = "zip"
-	} else {
-		ext = "tar.gz"
+	// 重新建立软链接
+	previous := inuse(goroot)
+	_ = os.Remove(goroot)
+
+	if err = mkSymlink(filepath.Join(versionsDir, dirName), goroot); err != nil {
+		return cli.Exit(errstring(err), 1)
 	}
-	filename := filepath.Join(downloadsDir, fmt.Sprintf("go%s.%s-%s.%s", vname, runtime.GOOS, runtime.GOARCH, ext))
+	appendHistory(historyUse, dirName, previous)
+	fmt.Printf("Now using go%s\n", dirName)
+	return nil
+}
 
+// fetchPackage 下载安装包（本地已存在则复用）并检查校验和，下载进度等信息输出至out。
+func fetchPackage(pkg *version.Package, filename string, skipChecksum bool, out io.Writer) (err error) {
 	if _, err = os.Stat(filename); os.IsNotExist(err) {
 		// 本地不存在安装包，从远程下载并检查校验和。
-		if _, err = pkg.DownloadWithProgress(filename); err != nil {
+		if _, err = pkg.DownloadWithProgressTo(filename, out); err != nil {
 			return cli.Exit(errstring(err), 1)
 		}
 
 		if !skipChecksum {
-			fmt.Println("Computing checksum with", pkg.Algorithm)
+			_, _ = fmt.Fprintln(out, "Computing checksum with", pkg.Algorithm)
 			if err = pkg.VerifyChecksum(filename); err != nil {
 				return cli.Exit(errstring(err), 1)
 			}
-			fmt.Println("Checksums matched")
+			_, _ = fmt.Fprintln(out, "Checksums matched")
 		}
 
 	} else {
 		if !skipChecksum {
 			// 本地存在安装包，检查校验和。
-			fmt.Println("Computing checksum with", pkg.Algorithm)
+			_, _ = fmt.Fprintln(out, "Computing checksum with", pkg.Algorithm)
 			if err = pkg.VerifyChecksum(filename); err != nil {
 				_ = os.Remove(filename)
 				return cli.Exit(errstring(err), 1)
 			}
-			fmt.Println("Checksums matched")
+			_, _ = fmt.Fprintln(out, "Checksums matched")
 		}
 	}
+	return nil
+}
 
-	// 删除可能存在的历史垃圾文件
-	_ = os.RemoveAll(filepath.Join(versionsDir, "go"))
+// selectPackage 从候选安装包中选出待安装的安装包。非交互模式下不会弹出选择菜单。
+func selectPackage(ctx *cli.Context, pkgs []version.Package) (pkg version.Package, err error) {
+	if filename := ctx.String("package"); filename != "" {
+		for i := range pkgs {
+			if pkgs[i].FileName == filename {
+				return pkgs[i], nil
+			}
+		}
+		return pkg, cli.Exit(wrapstring(fmt.Sprintf("package %q not found, candidates are: %s", filename, strings.Join(packageFileNames(pkgs), ", "))), 1)
+	}
 
-	// 解压安装包
-	if err = archiver.Unarchive(filename, versionsDir); err != nil {
-		return cli.Exit(errstring(err), 1)
+	if len(pkgs) == 1 || ctx.Bool("yes") {
+		return pkgs[0], nil
 	}
-	// 目录重命名
-	if err = os.Rename(filepath.Join(versionsDir, "go"), targetV); err != nil {
-		return cli.Exit(errstring(err), 1)
+
+	if !interactive() {
+		return pkg, cli.Exit(wrapstring(fmt.Sprintf("multiple packages found, please specify one with --package: %s", strings.Join(packageFileNames(pkgs), ", "))), exitCodePackageAmbiguous)
 	}
 
-	if ctx.Bool("nouse") {
+	menu := wmenu.NewMenu("Please select the package you want to install.")
+	menu.AddColor(
+		wlog.Color{Code: ct.Green},
+		wlog.Color{Code: ct.Yellow},
+		wlog.Color{Code: ct.Magenta},
+		wlog.Color{Code: ct.Yellow},
+	)
+	menu.Action(func(opts []wmenu.Opt) error {
+		pkg = opts[0].Value.(version.Package)
 		return nil
+	})
+	for i := range pkgs {
+		if i == 0 {
+			menu.Option(pkgs[i].FileName, pkgs[i], true, nil)
+		} else {
+			menu.Option(" "+pkgs[i].FileName, pkgs[i], false, nil)
+		}
+	}
+	if err = menu.Run(); err != nil {
+		return pkg, cli.Exit(errstring(err), 1)
 	}
+	return pkg, nil
+}
 
-	// 重新建立软链接
-	_ = os.Remove(goroot)
+// confirmSkipChecksum 在安装包缺少校验和时确认是否跳过校验。非交互模式下仅由命令行参数决定。
+func confirmSkipChecksum(ctx *cli.Context, pkg version.Package) (skip bool, err error) {
+	if pkg.Checksum != "" || pkg.ChecksumURL != "" {
+		return false, nil
+	}
+	if ctx.Bool("no-checksum-ok") {
+		return true, nil
+	}
+	if ctx.Bool("yes") || !interactive() {
+		return false, cli.Exit(wrapstring("checksum file not found, use --no-checksum-ok to install without verification"), exitCodeChecksumNotFound)
+	}
 
-	if err = mkSymlink(targetV, goroot); err != nil {
-		return cli.Exit(errstring(err), 1)
+	menu := wmenu.NewMenu("Checksum file not found, do you want to continue?")
+	menu.IsYesNo(wmenu.DefN)
+	menu.Action(func(opts []wmenu.Opt) error {
+		skip = opts[0].Value.(string) == "yes"
+		return nil
+	})
+	if err = menu.Run(); err != nil {
+		return false, cli.Exit(errstring(err), 1)
 	}
-	fmt.Printf("Now using go%s\n", v.Name())
-	return nil
+	return skip, nil
+}
+
+func packageFileNames(pkgs []version.Package) []string {
+	names := make([]string, 0, len(pkgs))
+	for i := range pkgs {
+		names = append(names, pkgs[i].FileName)
+	}
+	return names
 }
 
 func mkSymlink(oldname, newname string) (err error) {
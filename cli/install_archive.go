@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"archive/tar"
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/klauspost/compress/zip"
+	"github.com/mholt/archiver/v3"
+	"github.com/urfave/cli/v2"
+	"github.com/voidint/g/pkg/checksum"
+	"github.com/voidint/g/version"
+)
+
+// installFromArchive 从本地安装包安装，不访问任何版本采集器。
+func installFromArchive(ctx *cli.Context) (err error) {
+	filename := ctx.String("archive")
+	if finfo, err := os.Stat(filename); err != nil || finfo.IsDir() {
+		return cli.Exit(fmt.Sprintf("[g] Archive %q does not exist.", filename), 1)
+	}
+
+	base := filepath.Base(filename)
+	if isInstallerFile(base) {
+		return cli.Exit(wrapstring(fmt.Sprintf("archive %q is an installer that cannot be extracted, please use the .tar.gz or .zip archive instead", base)), 1)
+	}
+
+	// 优先从文件名中解析版本号及平台，失败时再读取安装包内的VERSION文件。
+	goos, goarch, _ := version.ParsePlatform(base)
+	vname := version.ParseVersionName(base)
+	if vname == "" {
+		if vname, err = readArchiveVersion(filename); err != nil {
+			return cli.Exit(errstring(err), 1)
+		}
+	}
+	if goos == "" {
+		goos, goarch = runtime.GOOS, runtime.GOARCH
+	}
+	if goos != runtime.GOOS {
+		return cli.Exit(wrapstring(fmt.Sprintf("archive %q is built for %s/%s and cannot be installed on %s", base, goos, goarch, runtime.GOOS)), 1)
+	}
+	// 非本机硬件架构的安装包须通过--arch选项显式指定，与从镜像站点安装时的行为一致。
+	if wantArch := ctx.String("arch"); wantArch == "" && goarch != runtime.GOARCH {
+		return cli.Exit(wrapstring(fmt.Sprintf("archive %q is built for %s/%s rather than %s/%s, specify --arch %s to install it anyway", base, goos, goarch, runtime.GOOS, runtime.GOARCH, goarch)), 1)
+	} else if wantArch != "" && goarch != wantArch {
+		return cli.Exit(wrapstring(fmt.Sprintf("archive %q is built for %s/%s rather than %s/%s", base, goos, goarch, runtime.GOOS, wantArch)), 1)
+	}
+
+	v, err := version.New(vname)
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	if expected := ctx.Args().First(); expected != "" && expected != v.Name() {
+		return cli.Exit(wrapstring(fmt.Sprintf("archive %q contains go%s rather than go%s", base, v.Name(), expected)), 1)
+	}
+
+	vname = installedDirName(v.Name(), goarch)
+	targetV := filepath.Join(versionsDir, vname)
+
+	// 检查版本是否已经安装
+	if finfo, err := os.Stat(targetV); err == nil && finfo.IsDir() {
+		return cli.Exit(fmt.Sprintf("[g] %q version has been installed.", vname), 1)
+	}
+
+	if expected := strings.ToLower(ctx.String("checksum")); expected != "" {
+		fmt.Println("Computing checksum with", checksum.SHA256)
+		if err = checksum.VerifyFile(checksum.SHA256, expected, filename); err != nil {
+			return cli.Exit(errstring(err), 1)
+		}
+		fmt.Println("Checksums matched")
+	}
+
+	if err = extractArchive(filename, vname); err != nil {
+		return err
+	}
+	appendHistory(historyInstall, vname, "")
+	return useInstalled(ctx, vname)
+}
+
+// isInstallerFile 返回文件是否为可安装程序（.pkg、.msi），此类文件无法解压安装。
+func isInstallerFile(filename string) bool {
+	filename = strings.ToLower(filename)
+	return strings.HasSuffix(filename, ".pkg") || strings.HasSuffix(filename, ".msi")
+}
+
+// readArchiveVersion 读取安装包内go/VERSION文件中的版本号
+func readArchiveVersion(filename string) (vname string, err error) {
+	err = archiver.Walk(filename, func(f archiver.File) error {
+		var name string
+		switch h := f.Header.(type) {
+		case *tar.Header:
+			name = h.Name
+		case zip.FileHeader:
+			name = h.Name
+		}
+		if strings.TrimPrefix(name, "./") != "go/VERSION" {
+			return nil
+		}
+
+		scanner := bufio.NewScanner(f)
+		if scanner.Scan() {
+			vname = strings.TrimPrefix(strings.TrimSpace(scanner.Text()), "go")
+		}
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+		return archiver.ErrStopWalk
+	})
+	if err != nil {
+		return "", err
+	}
+	if vname == "" {
+		return "", fmt.Errorf("version file not found in archive %q", filepath.Base(filename))
+	}
+	return vname, nil
+}
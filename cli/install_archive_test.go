@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mholt/archiver/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_readArchiveVersion(t *testing.T) {
+	rootDir := filepath.Join(os.TempDir(), fmt.Sprintf(".g_%d", time.Now().UnixNano()))
+	defer os.RemoveAll(rootDir)
+
+	goDir := filepath.Join(rootDir, "go")
+	_ = os.MkdirAll(goDir, 0755)
+	assert.Nil(t, os.WriteFile(filepath.Join(goDir, "VERSION"), []byte("go1.22.3\ntime 2024-05-01T19:59:44Z\n"), 0644))
+
+	for _, ext := range []string{"tar.gz", "zip"} {
+		t.Run(fmt.Sprintf("读取%s安装包中的版本号", ext), func(t *testing.T) {
+			filename := filepath.Join(rootDir, "archive."+ext)
+			assert.Nil(t, archiver.Archive([]string{goDir}, filename))
+
+			vname, err := readArchiveVersion(filename)
+			assert.Nil(t, err)
+			assert.Equal(t, "1.22.3", vname)
+		})
+	}
+
+	t.Run("安装包中不存在VERSION文件", func(t *testing.T) {
+		_ = os.Remove(filepath.Join(goDir, "VERSION"))
+		filename := filepath.Join(rootDir, "empty.tar.gz")
+		assert.Nil(t, archiver.Archive([]string{goDir}, filename))
+
+		_, err := readArchiveVersion(filename)
+		assert.NotNil(t, err)
+	})
+}
+
+func Test_isInstallerFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		want     bool
+	}{
+		{name: "macOS可安装程序", filename: "go1.22.3.darwin-arm64.pkg", want: true},
+		{name: "Windows可安装程序", filename: "go1.22.3.windows-amd64.MSI", want: true},
+		{name: "tar.gz压缩包", filename: "go1.22.3.linux-amd64.tar.gz", want: false},
+		{name: "zip压缩包", filename: "go1.22.3.windows-amd64.zip", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isInstallerFile(tt.filename))
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+	"github.com/voidint/g/version"
+)
+
+// newInstallContext 返回带有install命令安装包相关选项的命令行上下文
+func newInstallContext(t *testing.T, args ...string) *cli.Context {
+	set := flag.NewFlagSet("install", flag.ContinueOnError)
+	set.Bool("yes", false, "")
+	set.Bool("no-checksum-ok", false, "")
+	set.String("package", "", "")
+	set.Bool("prerelease", false, "")
+	assert.Nil(t, set.Parse(args))
+	return cli.NewContext(nil, set, nil)
+}
+
+// exitCode 返回错误对应的退出码，非cli.ExitCoder类型的错误返回-1。
+func exitCode(err error) int {
+	if ec, ok := err.(cli.ExitCoder); ok {
+		return ec.ExitCode()
+	}
+	return -1
+}
+
+func Test_selectPackage(t *testing.T) {
+	patches := gomonkey.ApplyFunc(interactive, func() bool { return false })
+	defer patches.Reset()
+
+	pkgs := []version.Package{
+		{FileName: "go1.22.3.linux-arm64.tar.gz"},
+		{FileName: "go1.22.3.linux-arm64-custom.tar.gz"},
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		pkgs     []version.Package
+		want     string
+		wantCode int
+	}{
+		{name: "仅有一个安装包", pkgs: pkgs[:1], want: pkgs[0].FileName},
+		{name: "--yes选择第一个安装包", args: []string{"--yes"}, pkgs: pkgs, want: pkgs[0].FileName},
+		{name: "--package指定安装包", args: []string{"--package", pkgs[1].FileName}, pkgs: pkgs, want: pkgs[1].FileName},
+		{name: "--package指定的安装包不存在", args: []string{"--package", "go1.22.3.linux-386.tar.gz"}, pkgs: pkgs, wantCode: 1},
+		{name: "非交互模式下存在多个安装包", pkgs: pkgs, wantCode: exitCodePackageAmbiguous},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg, err := selectPackage(newInstallContext(t, tt.args...), tt.pkgs)
+			if tt.wantCode != 0 {
+				assert.Equal(t, tt.wantCode, exitCode(err))
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, pkg.FileName)
+		})
+	}
+}
+
+func Test_confirmSkipChecksum(t *testing.T) {
+	patches := gomonkey.ApplyFunc(interactive, func() bool { return false })
+	defer patches.Reset()
+
+	withChecksum := version.Package{FileName: "go1.22.3.linux-amd64.tar.gz", Checksum: "8920ea521bad8f6b7bc377b4824982e011c19af27df88a815e3586ea895f1b36"}
+	withoutChecksum := version.Package{FileName: "go1.22.3.linux-amd64.tar.gz"}
+
+	tests := []struct {
+		name     string
+		args     []string
+		pkg      version.Package
+		wantSkip bool
+		wantCode int
+	}{
+		{name: "存在校验和", pkg: withChecksum},
+		{name: "存在校验和时忽略--no-checksum-ok", args: []string{"--no-checksum-ok"}, pkg: withChecksum},
+		{name: "--no-checksum-ok跳过校验", args: []string{"--no-checksum-ok"}, pkg: withoutChecksum, wantSkip: true},
+		{name: "--yes不会跳过校验", args: []string{"--yes"}, pkg: withoutChecksum, wantCode: exitCodeChecksumNotFound},
+		{name: "非交互模式下缺少校验和", pkg: withoutChecksum, wantCode: exitCodeChecksumNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			skip, err := confirmSkipChecksum(newInstallContext(t, tt.args...), tt.pkg)
+			if tt.wantCode != 0 {
+				assert.Equal(t, tt.wantCode, exitCode(err))
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantSkip, skip)
+		})
+	}
+}
+
+func Test_installRemote_supportCache(t *testing.T) {
+	oldGhomeDir := ghomeDir
+	t.Cleanup(func() { ghomeDir = oldGhomeDir })
+	ghomeDir = t.TempDir()
+
+	remote := []*version.Version{newRemote("1.20.14"), newRemote("1.21.6"), newRemote("1.22.3")}
+	patches := gomonkey.ApplyFunc(remoteVersions, func() ([]*version.Version, error) { return remote, nil })
+	defer patches.Reset()
+	patches.ApplyFunc(installVersion, func(_ *cli.Context, v *version.Version, goarch string, _ io.Writer) (string, error) {
+		return installedDirName(v.Name(), goarch), nil
+	})
+
+	t.Run("安装时刷新维护状态缓存", func(t *testing.T) {
+		dirName, err := installRemote(newInstallContext(t), "1.22.3", "")
+		assert.Nil(t, err)
+		assert.Equal(t, "1.22.3", dirName)
+
+		lines, fresh := cachedSupportedLines()
+		assert.True(t, fresh)
+		assert.Equal(t, []string{"1.22", "1.21"}, lines)
+	})
+}
@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/urfave/cli/v2"
+)
+
+func local(ctx *cli.Context) (err error) {
+	vname := ctx.Args().First()
+	if vname == "" {
+		filename, pv, err := projectVersion()
+		if err != nil {
+			return cli.Exit(errstring(err), 1)
+		}
+		if pv == "" {
+			return cli.ShowSubcommandHelp(ctx)
+		}
+		fmt.Printf("%s (set by %s)\n", pv, filename)
+		return nil
+	}
+
+	// 版本文件中既可以是具体版本号，也可以是版本约束。
+	if err = validateVersionArg(vname); err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	filename := filepath.Join(wd, goVersionFile)
+	if err = os.WriteFile(filename, []byte(vname+"\n"), 0644); err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	fmt.Printf("Wrote %s to %s\n", vname, filename)
+	return nil
+}
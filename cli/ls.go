@@ -2,8 +2,6 @@ package cli
 
 import (
 	"fmt"
-	"os"
-	"sort"
 
 	"github.com/k0kubun/go-ansi"
 	"github.com/urfave/cli/v2"
@@ -11,33 +9,80 @@ import (
 )
 
 func list(ctx *cli.Context) (err error) {
-	dirs, err := os.ReadDir(versionsDir)
-	if err != nil || len(dirs) <= 0 {
+	items := installedVersions()
+	if len(items) <= 0 {
 		fmt.Printf("No version installed yet\n\n")
 		return nil
 	}
-	items := make([]*version.Version, 0, len(dirs))
-	for _, d := range dirs {
-		if !d.IsDir() {
-			continue
+
+	if vname := ctx.Args().First(); vname != "" {
+		// 仅列出满足条件的已安装版本，不限制硬件架构。
+		target, _ := expandAlias(vname)
+		if items, err = version.NewFinder(items, version.WithFinderGoarch("")).FindAll(target); err != nil {
+			fmt.Printf("No installed version matches %q\n\n", vname)
+			return nil
+		}
+	}
+
+	// 仅--unsupported需要可靠的维护状态，此时允许在限定时间内刷新过期的缓存；其余情况仅读取缓存。
+	supported, _ := cachedSupportedLines()
+	if ctx.Bool("unsupported") {
+		supported = refreshSupportedLines(remoteQueryTimeout)
+		if len(supported) == 0 {
+			return cli.Exit(wrapstring("support status is unavailable, please check your network and try again."), 1)
 		}
+		items = filterUnsupported(items, supported)
+		if len(items) == 0 {
+			fmt.Printf("No unsupported version installed\n\n")
+			return nil
+		}
+	}
+
+	items = listVersions(ctx, items)
+	mode := outputMode(ctx)
 
-		v, err := version.New(d.Name())
-		if err != nil || v == nil {
-			continue
+	// wide、table模式下展示各版本适用于本机平台的安装包信息。默认使用已安装目录的信息，
+	// 仅在限定时间内尝试从远程站点补充安装包大小，超时或失败时不影响列表的展示。
+	var remote *version.Finder
+	tabular := mode == wideMode || mode == tableMode
+	if tabular {
+		if vs, err := remoteVersionsWithin(remoteQueryTimeout); err == nil {
+			remote = version.NewFinder(vs, version.WithFinderPackageKind(""), version.WithFinderGoos(""), version.WithFinderGoarch(""))
 		}
-		items = append(items, v)
-		sort.Sort(version.Collection(items))
 	}
 
-	var renderMode uint8
-	switch ctx.String("output") {
-	case "json":
-		renderMode = jsonMode
-	default:
-		renderMode = textMode
+	inused := inuse(goroot)
+	vs := make([]versionOut, 0, len(items))
+	for _, item := range items {
+		goarch := installedArch(item)
+		vo := versionOut{
+			Version:   item.Name(),
+			Arch:      goarch,
+			Installed: true,
+			Support:   supportStatus(item, supported),
+		}
+		vo.InUse = vo.dirName() == inused
+		if tabular {
+			vo.Packages = item.Packages()
+		}
+		if remote != nil {
+			if v, err := remote.Find(item.Name()); err == nil {
+				vo.Packages = v.Packages()
+			}
+		}
+		vs = append(vs, vo)
 	}
 
-	render(renderMode, installed(), items, ansi.NewAnsiStdout())
+	renderVersions(mode, vs, ansi.NewAnsiStdout())
 	return nil
 }
+
+// filterUnsupported 返回已停止维护的版本
+func filterUnsupported(items []*version.Version, supported []string) (unsupported []*version.Version) {
+	for _, item := range items {
+		if supportStatus(item, supported) == supportUnsupported {
+			unsupported = append(unsupported, item)
+		}
+	}
+	return unsupported
+}
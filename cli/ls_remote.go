@@ -4,7 +4,6 @@ import (
 	"os"
 	"strings"
 
-	"github.com/Masterminds/semver/v3"
 	"github.com/k0kubun/go-ansi"
 	"github.com/urfave/cli/v2"
 	"github.com/voidint/g/collector"
@@ -20,9 +19,8 @@ const (
 func listRemote(ctx *cli.Context) (err error) {
 	vname := ctx.Args().First()
 
-	var cs *semver.Constraints
-	if vname != "" && vname != stableChannel && vname != unstableChannel && vname != archivedChannel && vname != version.Latest {
-		if cs, err = semver.NewConstraint(vname); err != nil {
+	if vname != "" && vname != stableChannel && vname != unstableChannel && vname != archivedChannel {
+		if err = validateVersionArg(vname); err != nil {
 			return cli.Exit(errstring(err), 1)
 		}
 	}
@@ -33,9 +31,15 @@ func listRemote(ctx *cli.Context) (err error) {
 	}
 
 	var vs []*version.Version
+	var supported []string
 	switch vname {
 	case stableChannel:
 		vs, err = c.StableVersions()
+		if err == nil {
+			// 稳定版本中已包含维护中的各次版本
+			supported = supportedLines(vs)
+			writeSupportCache(supported)
+		}
 	case unstableChannel:
 		vs, err = c.UnstableVersions()
 	case archivedChannel:
@@ -43,18 +47,10 @@ func listRemote(ctx *cli.Context) (err error) {
 	default:
 		vs, err = c.AllVersions()
 		if err == nil {
-			if vname == version.Latest {
-				vs = []*version.Version{vs[len(vs)-1]}
-			}
-
-			if vname != "" && vname != version.Latest {
-				var newVs []*version.Version
-				for _, v := range vs {
-					if v.MatchConstraint(cs) {
-						newVs = append(newVs, v)
-					}
-				}
-				vs = newVs
+			supported = supportedLines(vs)
+			writeSupportCache(supported)
+			if vname != "" {
+				vs, err = findRemote(vs, vname)
 			}
 		}
 
@@ -62,15 +58,60 @@ func listRemote(ctx *cli.Context) (err error) {
 	if err != nil {
 		return cli.Exit(errstring(err), 1)
 	}
+	if supported == nil {
+		// 预发布及归档版本中无法得出维护中的次版本，仅读取缓存，不再访问远程站点。
+		supported, _ = cachedSupportedLines()
+	}
 
-	var renderMode uint8
-	switch ctx.String("output") {
-	case "json":
-		renderMode = jsonMode
-	default:
-		renderMode = textMode
+	if kind, goos, goarch := ctx.String("kind"), ctx.String("os"), ctx.String("arch"); kind != "" || goos != "" || goarch != "" {
+		vs = filterPackages(vs, version.PackageKind(kind), goos, goarch)
 	}
 
-	render(renderMode, installed(), vs, ansi.NewAnsiStdout())
+	render(outputMode(ctx), installed(), supported, listVersions(ctx, vs), ansi.NewAnsiStdout())
 	return nil
 }
+
+// isVersionChannel 检查vname是否为版本通道关键字，如latest、oldstable。
+func isVersionChannel(vname string) bool {
+	switch vname {
+	case version.Latest, version.LatestUnstable, version.OldStable, version.Supported:
+		return true
+	}
+	return false
+}
+
+// findRemote 返回满足条件的远程版本，不限制软件包种类、操作系统和硬件架构。
+func findRemote(items []*version.Version, vname string) ([]*version.Version, error) {
+	return version.NewFinder(items,
+		version.WithFinderPackageKind(""),
+		version.WithFinderGoos(""),
+		version.WithFinderGoarch(""),
+	).FindAll(vname)
+}
+
+// filterPackages 返回包含指定种类、操作系统和硬件架构安装包的版本，每个版本仅保留匹配的安装包。参数为空时表示不限制该条件。
+// goarch既可以是规范化的硬件架构（如arm），也可以附带架构变体（如armv6l）。
+func filterPackages(items []*version.Version, kind version.PackageKind, goos, goarch string) (matched []*version.Version) {
+	for _, item := range items {
+		var pkgs []*version.Package
+		for _, pkg := range item.Packages() {
+			pkg := pkg
+			if !pkg.Match(kind, goos, "") {
+				continue
+			}
+			if goarch != "" && pkg.GOARCH != goarch && pkg.GOARCH+pkg.Variant != goarch {
+				continue
+			}
+			pkgs = append(pkgs, &pkg)
+		}
+		if len(pkgs) == 0 {
+			continue
+		}
+		v, err := version.New(item.Name(), version.WithPackages(pkgs))
+		if err != nil {
+			continue
+		}
+		matched = append(matched, v)
+	}
+	return matched
+}
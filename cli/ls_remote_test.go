@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/version"
+)
+
+func Test_filterPackages(t *testing.T) {
+	newVersion := func(vname string, filenames ...string) *version.Version {
+		pkgs := make([]*version.Package, 0, len(filenames))
+		for _, filename := range filenames {
+			kind := version.ArchiveKind
+			switch {
+			case filename == "go"+vname+".src.tar.gz":
+				kind = version.SourceKind
+			case strings.HasSuffix(filename, ".msi") || strings.HasSuffix(filename, ".pkg"):
+				kind = version.InstallerKind
+			}
+			pkgs = append(pkgs, &version.Package{FileName: filename, Kind: kind})
+		}
+		return version.MustNew(vname, version.WithPackages(pkgs))
+	}
+	items := []*version.Version{
+		newVersion("1.20.14", "go1.20.14.src.tar.gz", "go1.20.14.linux-amd64.tar.gz", "go1.20.14.linux-armv6l.tar.gz", "go1.20.14.windows-amd64.msi"),
+		newVersion("1.22.1", "go1.22.1.src.tar.gz", "go1.22.1.linux-amd64.tar.gz", "go1.22.1.linux-loong64.tar.gz", "go1.22.1.windows-amd64.msi"),
+	}
+	filenames := func(vs []*version.Version) map[string][]string {
+		out := make(map[string][]string, len(vs))
+		for _, v := range vs {
+			for _, pkg := range v.Packages() {
+				out[v.Name()] = append(out[v.Name()], pkg.FileName)
+			}
+		}
+		return out
+	}
+
+	tests := []struct {
+		name   string
+		kind   version.PackageKind
+		goos   string
+		goarch string
+		want   map[string][]string
+	}{
+		{
+			name:   "按操作系统和硬件架构筛选",
+			goos:   "linux",
+			goarch: "loong64",
+			want:   map[string][]string{"1.22.1": {"go1.22.1.linux-loong64.tar.gz"}},
+		},
+		{
+			name:   "硬件架构变体",
+			goarch: "armv6l",
+			want:   map[string][]string{"1.20.14": {"go1.20.14.linux-armv6l.tar.gz"}},
+		},
+		{
+			name: "按安装包种类筛选（忽略大小写）",
+			kind: "installer",
+			want: map[string][]string{
+				"1.20.14": {"go1.20.14.windows-amd64.msi"},
+				"1.22.1":  {"go1.22.1.windows-amd64.msi"},
+			},
+		},
+		{
+			name: "源码包不属于任何平台",
+			kind: version.SourceKind,
+			goos: "linux",
+			want: map[string][]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, filenames(filterPackages(items, tt.kind, tt.goos, tt.goarch)))
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/k0kubun/go-ansi"
+	"github.com/urfave/cli/v2"
+	"github.com/voidint/g/version"
+)
+
+// matrixResult 单个版本下命令的运行结果
+type matrixResult struct {
+	Version  string `json:"version"`
+	Passed   bool   `json:"passed"`
+	ExitCode int    `json:"exitCode"`
+	Elapsed  string `json:"elapsed"`
+	Error    string `json:"error,omitempty"`
+}
+
+// matrixReport 版本矩阵的运行报告
+type matrixReport struct {
+	Constraint string         `json:"constraint"`
+	Command    []string       `json:"command"`
+	Passed     int            `json:"passed"`
+	Failed     int            `json:"failed"`
+	Results    []matrixResult `json:"results"`
+}
+
+func matrix(ctx *cli.Context) (err error) {
+	args := ctx.Args().Slice()
+	if len(args) > 1 && args[1] == "--" {
+		args = append(args[:1], args[2:]...)
+	}
+	if len(args) < 2 {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+	constraint, cmdArgs := args[0], args[1:]
+
+	if err = validateVersionArg(constraint); err != nil {
+		return cli.Exit(errstring(fmt.Errorf("invalid version constraint %q", constraint)), 1)
+	}
+
+	// JSON模式下将安装进度及命令输出等信息输出至标准错误，以保证标准输出仅包含JSON报告。
+	var logOut io.Writer = ansi.NewAnsiStdout()
+	jsonOutput := ctx.String("output") == "json"
+	if jsonOutput {
+		if !ctx.Bool("yes") {
+			// 交互式菜单会污染标准输出，因此JSON模式下不允许弹出菜单。
+			return cli.Exit(wrapstring("-o json requires --yes because interactive prompts would corrupt the JSON output"), 1)
+		}
+		logOut = ansi.NewAnsiStderr()
+	}
+
+	items, err := remoteVersions()
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	writeSupportCache(supportedLines(items))
+	vs, err := matrixVersions(items, constraint, ctx.Bool("all"))
+	if err != nil {
+		return cli.Exit(fmt.Sprintf("[g] No version matches %q.", constraint), 1)
+	}
+
+	// 安装缺失的版本
+	for _, v := range vs {
+		if _, err = os.Stat(filepath.Join(versionsDir, v.Name())); err == nil {
+			continue
+		}
+		_, _ = fmt.Fprintf(logOut, "Installing go%s\n", v.Name())
+		dirName, err := installVersion(ctx, v, runtime.GOARCH, logOut)
+		if err != nil {
+			return err
+		}
+		if dirName == "" {
+			return cli.Exit(fmt.Sprintf("[g] Installation of go%s was canceled.", v.Name()), 1)
+		}
+	}
+
+	report := runMatrix(vs, cmdArgs, ctx.Int("parallel"), logOut)
+	report.Constraint = constraint
+
+	if filename := ctx.String("report"); filename != "" {
+		if err = writeMatrixReport(filename, report); err != nil {
+			return cli.Exit(errstring(err), 1)
+		}
+	}
+
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "    ")
+		_ = enc.Encode(report)
+	} else {
+		fmt.Println()
+		renderMatrixReport(report, os.Stdout)
+	}
+
+	if report.Failed > 0 {
+		return cli.Exit("", 1)
+	}
+	return nil
+}
+
+// matrixVersions 按升序返回满足条件且提供当前平台安装包的版本。all为false时，每个次版本仅保留最新的修订版本。
+func matrixVersions(items []*version.Version, vname string, all bool) ([]*version.Version, error) {
+	var opts []func(o *version.FindAllOptions)
+	if !all {
+		opts = append(opts, version.WithFindAllLatestPerMinor())
+	}
+	return version.NewFinder(items).FindAll(vname, opts...)
+}
+
+// runMatrix 依次（或并发）在各版本下运行命令，命令的输出写入out。并发运行时，各版本的输出在命令结束后整体打印，以免相互交错。
+func runMatrix(vs []*version.Version, args []string, parallel int, out io.Writer) *matrixReport {
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	report := matrixReport{
+		Command: args,
+		Results: make([]matrixResult, len(vs)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, parallel)
+
+	for i := range vs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			vname := vs[i].Name()
+			cmdOut := out
+			var buf bytes.Buffer
+			if parallel > 1 {
+				cmdOut = &buf
+			} else {
+				_, _ = color.New(color.FgCyan).Fprintf(out, "==> go%s: %s\n", vname, strings.Join(args, " "))
+			}
+
+			start := time.Now()
+			code, err := runWithVersion(filepath.Join(versionsDir, vname), args, nil, cmdOut, cmdOut)
+			result := matrixResult{
+				Version:  vname,
+				Passed:   err == nil && code == 0,
+				ExitCode: code,
+				Elapsed:  time.Since(start).Round(time.Millisecond).String(),
+			}
+			if err != nil {
+				result.Error = err.Error()
+			}
+			report.Results[i] = result
+
+			if parallel > 1 {
+				mu.Lock()
+				_, _ = color.New(color.FgCyan).Fprintf(out, "==> go%s: %s\n", vname, strings.Join(args, " "))
+				_, _ = buf.WriteTo(out)
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for _, result := range report.Results {
+		if result.Passed {
+			report.Passed++
+		} else {
+			report.Failed++
+		}
+	}
+	return &report
+}
+
+// renderMatrixReport 以表格形式输出版本矩阵的运行结果
+func renderMatrixReport(report *matrixReport, out io.Writer) {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintln(w, "VERSION\tRESULT\tEXIT CODE\tELAPSED")
+	for _, result := range report.Results {
+		status := "PASS"
+		if !result.Passed {
+			status = "FAIL"
+		}
+		_, _ = fmt.Fprintf(w, "go%s\t%s\t%d\t%s\n", result.Version, status, result.ExitCode, result.Elapsed)
+	}
+	_ = w.Flush()
+	_, _ = fmt.Fprintf(out, "\n%d passed, %d failed\n", report.Passed, report.Failed)
+}
+
+func writeMatrixReport(filename string, report *matrixReport) error {
+	data, err := json.MarshalIndent(report, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, append(data, '\n'), 0644)
+}
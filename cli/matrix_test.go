@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/version"
+)
+
+func Test_matrixVersions(t *testing.T) {
+	items := []*version.Version{
+		newRemoteOS("1.22.1", runtime.GOOS),
+		newRemoteOS("1.20.14", runtime.GOOS),
+		newRemoteOS("1.21.6", runtime.GOOS),
+		newRemoteOS("1.21.5", runtime.GOOS),
+		newRemoteOS("1.22.0", runtime.GOOS),
+		newRemoteOS("1.22.2", "plan9"),
+		newRemoteOS("1.23rc1", runtime.GOOS),
+	}
+	names := func(vs []*version.Version) []string {
+		out := make([]string, 0, len(vs))
+		for _, v := range vs {
+			out = append(out, v.Name())
+		}
+		return out
+	}
+
+	t.Run("每个次版本仅保留最新的修订版本", func(t *testing.T) {
+		vs, err := matrixVersions(items, ">= 1.21", false)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"1.21.6", "1.22.1"}, names(vs))
+	})
+
+	t.Run("保留所有修订版本", func(t *testing.T) {
+		vs, err := matrixVersions(items, ">= 1.21", true)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"1.21.5", "1.21.6", "1.22.0", "1.22.1"}, names(vs))
+	})
+
+	t.Run("无满足约束的版本", func(t *testing.T) {
+		_, err := matrixVersions(items, "< 1.20", false)
+		assert.NotNil(t, err)
+	})
+}
+
+func Test_runMatrix(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("depends on sh")
+	}
+	oldVersionsDir := versionsDir
+	t.Cleanup(func() { versionsDir = oldVersionsDir })
+	versionsDir = t.TempDir()
+	for _, vname := range []string{"1.21.6", "1.22.1"} {
+		assert.Nil(t, os.MkdirAll(filepath.Join(versionsDir, vname, "bin"), 0755))
+	}
+	vs := []*version.Version{version.MustNew("1.21.6"), version.MustNew("1.22.1")}
+
+	t.Run("命令输出写入指定的Writer", func(t *testing.T) {
+		var out strings.Builder
+		report := runMatrix(vs, []string{"sh", "-c", "echo $GOROOT"}, 1, &out)
+		assert.Equal(t, 2, report.Passed)
+		assert.Equal(t, 0, report.Failed)
+		assert.Contains(t, out.String(), filepath.Join(versionsDir, "1.21.6")+"\n")
+		assert.Contains(t, out.String(), filepath.Join(versionsDir, "1.22.1")+"\n")
+	})
+
+	t.Run("命令失败", func(t *testing.T) {
+		var out strings.Builder
+		report := runMatrix(vs, []string{"sh", "-c", "exit 3"}, 2, &out)
+		assert.Equal(t, 0, report.Passed)
+		assert.Equal(t, 2, report.Failed)
+		assert.Equal(t, 3, report.Results[0].ExitCode)
+	})
+}
@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v2"
+	"github.com/voidint/g/version"
+)
+
+const (
+	failOnOutdated    = "outdated"
+	failOnUnsupported = "unsupported"
+)
+
+// outdatedItem 已安装版本的过期情况
+type outdatedItem struct {
+	Version   string `json:"version"`
+	Arch      string `json:"arch"`
+	InUse     bool   `json:"inUse"`
+	Latest    string `json:"latest"` // 所属次版本的最新修订版本
+	Outdated  bool   `json:"outdated"`
+	Supported bool   `json:"supported"`
+	Support   string `json:"support,omitempty"` // 维护状态：supported、unsupported或prerelease，未知时为空。
+}
+
+func outdated(ctx *cli.Context) (err error) {
+	failOn := ctx.String("fail-on")
+	if failOn != "" && failOn != failOnOutdated && failOn != failOnUnsupported {
+		return cli.Exit(errstring(fmt.Errorf("invalid value %q for --fail-on, allowed values are: [outdated|unsupported]", failOn)), 1)
+	}
+
+	remote, err := remoteVersions()
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+
+	writeSupportCache(supportedLines(remote))
+
+	items := checkOutdated(installedVersions(), remote)
+	inused := inuse(goroot)
+	for i := range items {
+		items[i].InUse = installedDirName(items[i].Version, items[i].Arch) == inused
+	}
+
+	switch ctx.String("output") {
+	case "json":
+		if items == nil {
+			items = []outdatedItem{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "    ")
+		_ = enc.Encode(items)
+	default:
+		renderOutdated(items, os.Stdout)
+	}
+
+	for _, item := range items {
+		if (failOn == failOnOutdated && item.Outdated) || (failOn == failOnUnsupported && item.Support == supportUnsupported) {
+			return cli.Exit("", exitCodeOutdated)
+		}
+	}
+	return nil
+}
+
+// checkOutdated 将已安装版本与远程版本比较，返回各版本所属次版本的最新修订版本及维护状态。
+// 是否过期按次版本（区分硬件架构）判断：已安装该次版本的最新修订版本时，同一次版本的其余已安装版本均不视为过期。
+func checkOutdated(installed, remote []*version.Version) (items []outdatedItem) {
+	type lineKey struct{ line, arch string }
+
+	lines := supportedLines(remote)
+
+	// 各次版本中已安装的最高修订版本
+	highest := make(map[lineKey]*version.Version)
+	for _, v := range installed {
+		key := lineKey{line: minorLine(v), arch: installedArch(v)}
+		if cur, ok := highest[key]; !ok || (version.Collection{cur, v}).Less(0, 1) {
+			highest[key] = v
+		}
+	}
+
+	for _, v := range installed {
+		goarch := installedArch(v)
+		item := outdatedItem{
+			Version: v.Name(),
+			Arch:    goarch,
+			Latest:  v.Name(),
+			Support: supportStatus(v, lines),
+		}
+		item.Supported = item.Support == supportSupported
+
+		latest, err := latestPatch(version.NewFinder(remote,
+			version.WithFinderGoos(runtime.GOOS),
+			version.WithFinderGoarch(goarch),
+		), v)
+		if err == nil && (version.Collection{v, latest}).Less(0, 1) {
+			item.Latest = latest.Name()
+			current := highest[lineKey{line: minorLine(v), arch: goarch}]
+			item.Outdated = (version.Collection{current, latest}).Less(0, 1)
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func renderOutdated(items []outdatedItem, out io.Writer) {
+	if len(items) == 0 {
+		_, _ = fmt.Fprintf(out, "No version installed yet\n\n")
+		return
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintln(w, "VERSION\tLATEST\tSUPPORTED\tSTATUS")
+	for _, item := range items {
+		name := installedDirName(item.Version, item.Arch)
+		if item.InUse {
+			name = "* " + name
+		} else {
+			name = "  " + name
+		}
+		status := "up to date"
+		if item.Outdated {
+			status = "outdated"
+		}
+		supported := "no"
+		switch item.Support {
+		case supportSupported:
+			supported = "yes"
+		case supportPrerelease:
+			supported = supportPrerelease // 尚未发布正式版本的次版本不视为停止维护
+		case supportUnsupported:
+			status += ", unsupported"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", name, item.Latest, supported, status)
+	}
+	_ = w.Flush()
+}
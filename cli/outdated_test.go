@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/version"
+)
+
+func Test_checkOutdated(t *testing.T) {
+	remote := []*version.Version{
+		newRemote("1.20.14"),
+		newRemote("1.21.5"),
+		newRemote("1.21.6"),
+		newRemote("1.22.0"),
+		newRemote("1.22.1"),
+	}
+
+	t.Run("比较已安装版本与远程版本", func(t *testing.T) {
+		items := checkOutdated([]*version.Version{
+			newInstalled("1.20.14"),
+			newInstalled("1.21.5"),
+			newInstalled("1.22.1"),
+		}, remote)
+
+		assert.Equal(t, []outdatedItem{
+			{Version: "1.20.14", Arch: runtime.GOARCH, Latest: "1.20.14", Outdated: false, Supported: false, Support: supportUnsupported},
+			{Version: "1.21.5", Arch: runtime.GOARCH, Latest: "1.21.6", Outdated: true, Supported: true, Support: supportSupported},
+			{Version: "1.22.1", Arch: runtime.GOARCH, Latest: "1.22.1", Outdated: false, Supported: true, Support: supportSupported},
+		}, items)
+	})
+
+	t.Run("已安装次版本的最新修订版本", func(t *testing.T) {
+		items := checkOutdated([]*version.Version{
+			newInstalled("1.21.5"),
+			newInstalled("1.21.6"),
+		}, remote)
+
+		assert.Equal(t, []outdatedItem{
+			{Version: "1.21.5", Arch: runtime.GOARCH, Latest: "1.21.6", Outdated: false, Supported: true, Support: supportSupported},
+			{Version: "1.21.6", Arch: runtime.GOARCH, Latest: "1.21.6", Outdated: false, Supported: true, Support: supportSupported},
+		}, items)
+	})
+
+	t.Run("未来次版本的预发布版本不视为停止维护", func(t *testing.T) {
+		items := checkOutdated([]*version.Version{newInstalled("1.23rc1")}, remote)
+		assert.Equal(t, []outdatedItem{
+			{Version: "1.23rc1", Arch: runtime.GOARCH, Latest: "1.23rc1", Outdated: false, Supported: false, Support: supportPrerelease},
+		}, items)
+	})
+
+	t.Run("未安装任何版本", func(t *testing.T) {
+		assert.Empty(t, checkOutdated(nil, remote))
+	})
+}
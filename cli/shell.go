@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+	"github.com/urfave/cli/v2"
+)
+
+// shellGorootEnv 记录由'g shell'为当前会话设置的GOROOT，以便取消时将其从PATH中移除。
+const shellGorootEnv = "G_SHELL_GOROOT"
+
+// sessionShell 返回目标shell。未通过--shell指定时，依据SHELL环境变量推断。
+func sessionShell(ctx *cli.Context) (shell string, err error) {
+	if shell = ctx.String("shell"); shell == "" {
+		shell = strings.TrimSuffix(filepath.Base(os.Getenv("SHELL")), ".exe")
+	}
+	if _, ok := hookScripts[shell]; !ok {
+		return "", cli.Exit(errstring(fmt.Errorf("unsupported shell %q, please specify one of [bash|zsh|fish] with --shell", shell)), 1)
+	}
+	return shell, nil
+}
+
+// shellEnv 输出将当前shell会话固定至某个已安装版本的shell代码，不改变全局的goroot软链接。
+func shellEnv(ctx *cli.Context) (err error) {
+	vname := ctx.Args().First()
+	if vname == "" && !ctx.Bool("unset") {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	shell, err := sessionShell(ctx)
+	if err != nil {
+		return err
+	}
+
+	hookRoot, shellRoot := os.Getenv(hookGorootEnv), os.Getenv(shellGorootEnv)
+
+	var targetV string
+	if ctx.Bool("unset") {
+		targetV = goroot
+	} else {
+		dirName, err := resolveInstalled(vname, ctx.String("arch"))
+		if err != nil {
+			return cli.Exit(wrapstring(fmt.Sprintf("the %q version does not exist, please install it first: %s", vname, err.Error())), 1)
+		}
+		targetV = filepath.Join(versionsDir, dirName)
+	}
+
+	path := switchGorootPath(os.Getenv("PATH"), hookRoot, targetV)
+	path = switchGorootPath(path, shellRoot, targetV)
+
+	var buf strings.Builder
+	buf.WriteString(exportEnv(shell, "GOROOT", targetV))
+	buf.WriteString(exportEnv(shell, "PATH", path))
+	if hookRoot != "" {
+		buf.WriteString(unsetEnv(shell, hookGorootEnv))
+	}
+	if ctx.Bool("unset") {
+		buf.WriteString(unsetEnv(shell, versionEnv))
+		buf.WriteString(unsetEnv(shell, shellGorootEnv))
+	} else {
+		buf.WriteString(exportEnv(shell, versionEnv, filepath.Base(targetV)))
+		buf.WriteString(exportEnv(shell, shellGorootEnv, targetV))
+	}
+	fmt.Print(buf.String())
+
+	if isatty.IsTerminal(os.Stdout.Fd()) {
+		evalCmd := fmt.Sprintf(`eval "$(g %s)"`, strings.Join(os.Args[1:], " "))
+		if shell == fishShell {
+			evalCmd = fmt.Sprintf("g %s | source", strings.Join(os.Args[1:], " "))
+		}
+		fmt.Fprintf(os.Stderr, "# To apply the changes to the current shell, run: %s\n", evalCmd)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"syscall"
+
+	"github.com/urfave/cli/v2"
+)
+
+// shimTools 需要生成垫片的工具
+var shimTools = []string{"go", "gofmt"}
+
+// shimsDir 返回垫片所在目录
+func shimsDir() string {
+	return filepath.Join(ghomeDir, "shims")
+}
+
+func installShims(ctx *cli.Context) (err error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+
+	dir := shimsDir()
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	for _, tool := range shimTools {
+		filename := filepath.Join(dir, tool+wrapperExt())
+		if _, err = os.Stat(filename); err == nil && !isWrapper(filename) {
+			fmt.Fprintf(os.Stderr, "[g] Skipped %s: the file was not generated by g\n", filename)
+			continue
+		}
+		if err = os.WriteFile(filename, []byte(shimScript(runtime.GOOS, exe, tool)), 0755); err != nil {
+			return cli.Exit(errstring(err), 1)
+		}
+		fmt.Printf("Created %s\n", filename)
+	}
+	fmt.Printf("\nPlease put %q in front of other go installations in the PATH environment variable.\n", dir)
+	return nil
+}
+
+func removeShims(ctx *cli.Context) (err error) {
+	for _, tool := range shimTools {
+		filename := filepath.Join(shimsDir(), tool+wrapperExt())
+		if !isWrapper(filename) {
+			continue
+		}
+		if err = os.Remove(filename); err != nil {
+			return cli.Exit(errstring(err), 1)
+		}
+		fmt.Printf("Removed %s\n", filename)
+	}
+	return nil
+}
+
+// shimScript 返回通过g运行指定工具的垫片脚本
+func shimScript(goos, exe, tool string) string {
+	if goos == "windows" {
+		return fmt.Sprintf("@echo off\r\nrem %s, do not edit.\r\n\"%s\" shim-exec %s %%*\r\n", wrapperMarker, exe, tool)
+	}
+	return fmt.Sprintf("#!/bin/sh\n# %s, do not edit.\nexec %s shim-exec %s \"$@\"\n", wrapperMarker, quoteShell(bashShell, exe), tool)
+}
+
+// shimExec 由垫片调用，解析当前应使用的版本并运行其中的工具。
+func shimExec(ctx *cli.Context) (err error) {
+	args := ctx.Args().Slice()
+	if len(args) == 0 {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	dirName, err := shimVersion()
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	targetV := filepath.Join(versionsDir, dirName)
+
+	tool := filepath.Join(targetV, "bin", args[0])
+	if runtime.GOOS == "windows" {
+		tool += ".exe"
+	}
+	if _, err = os.Stat(tool); err != nil {
+		return cli.Exit(wrapstring(fmt.Sprintf("%s is not found in go%s", args[0], dirName)), 1)
+	}
+
+	if runtime.GOOS == "windows" { // Windows不支持exec系统调用
+		code, err := runWithVersion(targetV, append([]string{tool}, args[1:]...), os.Stdin, os.Stdout, os.Stderr)
+		if err != nil {
+			return cli.Exit(errstring(err), 1)
+		}
+		os.Exit(code)
+	}
+
+	path := switchGorootPath(os.Getenv("PATH"), os.Getenv("GOROOT"), targetV)
+	if err = syscall.Exec(tool, append([]string{args[0]}, args[1:]...), versionEnviron(targetV, path)); err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	return nil
+}
+
+// shimVersion 返回垫片应使用的已安装版本目录名。依次查找：G_VERSION环境变量、项目版本文件、全局默认版本（即'g use'所切换的版本）。
+func shimVersion() (dirName string, err error) {
+	if vname := os.Getenv(versionEnv); vname != "" {
+		if finfo, err := os.Stat(filepath.Join(versionsDir, vname)); err == nil && finfo.IsDir() {
+			return vname, nil
+		}
+		if dirName, err = resolveInstalled(vname, ""); err != nil {
+			return "", fmt.Errorf("the %q version specified by %s is not installed", vname, versionEnv)
+		}
+		return dirName, nil
+	}
+
+	filename, vname, err := projectVersion()
+	if err != nil {
+		return "", err
+	}
+	if vname != "" {
+		if dirName, err = resolveInstalled(vname, ""); err != nil {
+			return "", fmt.Errorf("the %q version required by %s is not installed", vname, filename)
+		}
+		return dirName, nil
+	}
+
+	if dirName = inuse(goroot); dirName == "" {
+		return "", fmt.Errorf("no version is in use, please run 'g use <version>' first")
+	}
+	return dirName, nil
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_shimVersion(t *testing.T) {
+	oldGoroot, oldVersionsDir, oldRoot := goroot, versionsDir, versionFileRoot
+	wd, err := os.Getwd()
+	assert.Nil(t, err)
+	t.Cleanup(func() {
+		goroot, versionsDir, versionFileRoot = oldGoroot, oldVersionsDir, oldRoot
+		_ = os.Chdir(wd)
+	})
+
+	// 在临时目录中执行且查找不越过临时目录，避免其上级目录中的版本文件影响结果。
+	rootDir := t.TempDir()
+	assert.Nil(t, os.Chdir(rootDir))
+	versionFileRoot = rootDir
+	goroot = filepath.Join(rootDir, "go")
+	versionsDir = filepath.Join(rootDir, "versions")
+	for _, dirName := range []string{"1.21.6", "1.22.3"} {
+		assert.Nil(t, os.MkdirAll(filepath.Join(versionsDir, dirName), 0755))
+	}
+
+	t.Run("未设置任何版本", func(t *testing.T) {
+		t.Setenv(versionEnv, "")
+		_, err := shimVersion()
+		assert.NotNil(t, err)
+	})
+
+	t.Run("使用全局默认版本", func(t *testing.T) {
+		t.Setenv(versionEnv, "")
+		assert.Nil(t, mkSymlink(filepath.Join(versionsDir, "1.21.6"), goroot))
+		defer os.Remove(goroot)
+
+		dirName, err := shimVersion()
+		assert.Nil(t, err)
+		assert.Equal(t, "1.21.6", dirName)
+	})
+
+	t.Run("优先使用环境变量指定的版本", func(t *testing.T) {
+		t.Setenv(versionEnv, "~1.22")
+		dirName, err := shimVersion()
+		assert.Nil(t, err)
+		assert.Equal(t, "1.22.3", dirName)
+	})
+
+	t.Run("环境变量指定的版本未安装", func(t *testing.T) {
+		t.Setenv(versionEnv, "1.20.14")
+		_, err := shimVersion()
+		assert.NotNil(t, err)
+	})
+}
+
+func Test_installShims(t *testing.T) {
+	oldGhomeDir := ghomeDir
+	t.Cleanup(func() { ghomeDir = oldGhomeDir })
+	ghomeDir = t.TempDir()
+
+	userFile := filepath.Join(shimsDir(), "go"+wrapperExt())
+	assert.Nil(t, os.MkdirAll(shimsDir(), 0755))
+	assert.Nil(t, os.WriteFile(userFile, []byte("#!/bin/sh\necho user\n"), 0755))
+
+	t.Run("不覆盖并非由g生成的文件", func(t *testing.T) {
+		assert.Nil(t, installShims(nil))
+
+		data, err := os.ReadFile(userFile)
+		assert.Nil(t, err)
+		assert.Equal(t, "#!/bin/sh\necho user\n", string(data))
+		assert.True(t, isWrapper(filepath.Join(shimsDir(), "gofmt"+wrapperExt())))
+	})
+
+	t.Run("重新生成由g生成的垫片", func(t *testing.T) {
+		assert.Nil(t, os.Remove(userFile))
+		assert.Nil(t, installShims(nil))
+		assert.True(t, isWrapper(userFile))
+		assert.Nil(t, installShims(nil))
+		assert.True(t, isWrapper(userFile))
+	})
+}
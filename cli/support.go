@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/voidint/g/version"
+)
+
+// 版本的维护状态
+const (
+	supportSupported   = "supported"
+	supportUnsupported = "unsupported"
+	supportPrerelease  = "prerelease" // 尚未发布正式版本的次版本
+)
+
+// supportCacheTTL 维护状态缓存的有效期
+const supportCacheTTL = 24 * time.Hour
+
+// supportCache 维护状态缓存，避免离线或频繁执行命令时反复请求远程站点。
+type supportCache struct {
+	UpdatedAt time.Time `json:"updatedAt"`
+	Lines     []string  `json:"lines"`
+}
+
+// supportFile 返回维护状态缓存文件路径
+func supportFile() string {
+	return filepath.Join(ghomeDir, "supported")
+}
+
+// supportedLines 返回远程版本中官方仍在维护的次版本（降序），如['1.23', '1.22']。
+func supportedLines(remote []*version.Version) (lines []string) {
+	vs, err := version.NewFinder(remote,
+		version.WithFinderPackageKind(""),
+		version.WithFinderGoos(""),
+		version.WithFinderGoarch(""),
+	).FindSupported()
+	if err != nil {
+		return nil
+	}
+	for _, v := range vs {
+		lines = append(lines, minorLine(v))
+	}
+	return lines
+}
+
+// writeSupportCache 缓存维护中的次版本。缓存不影响命令本身，因此忽略写入错误。
+func writeSupportCache(lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	data, err := json.Marshal(supportCache{UpdatedAt: time.Now(), Lines: lines})
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(supportFile(), data, 0644)
+}
+
+// cachedSupportedLines 返回缓存的维护中的次版本（不访问网络），以及缓存是否仍在有效期内。维护状态未知时lines为nil。
+// 缓存由ls-remote、install、upgrade、outdated、info等本就需要访问远程站点的命令刷新。
+func cachedSupportedLines() (lines []string, fresh bool) {
+	var cache supportCache
+	data, err := os.ReadFile(supportFile())
+	if err != nil || json.Unmarshal(data, &cache) != nil {
+		return nil, false
+	}
+	return cache.Lines, len(cache.Lines) > 0 && time.Since(cache.UpdatedAt) < supportCacheTTL
+}
+
+// refreshSupportedLines 返回官方仍在维护的次版本。缓存过期时在限定时间内从远程站点重新获取，获取失败则退回过期的缓存。
+func refreshSupportedLines(timeout time.Duration) []string {
+	lines, fresh := cachedSupportedLines()
+	if fresh {
+		return lines
+	}
+	if remote, err := remoteVersionsWithin(timeout); err == nil {
+		if latest := supportedLines(remote); len(latest) > 0 {
+			writeSupportCache(latest)
+			return latest
+		}
+	}
+	return lines
+}
+
+// supportStatus 返回版本的维护状态。lines为空表示维护状态未知，此时返回空字符串。
+func supportStatus(v *version.Version, lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	line := minorLine(v)
+	for _, supported := range lines {
+		if line == supported {
+			if sv, err := version.Semantify(v.Name()); err == nil && sv.Prerelease() != "" {
+				return supportUnsupported // 已发布正式版本的次版本，其预发布版本不再维护。
+			}
+			return supportSupported
+		}
+	}
+
+	newest, err1 := version.Semantify(lines[0])
+	current, err2 := version.Semantify(line)
+	if err1 == nil && err2 == nil && current.GreaterThan(newest) {
+		return supportPrerelease
+	}
+	return supportUnsupported
+}
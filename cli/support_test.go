@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/version"
+)
+
+func Test_supportedLines(t *testing.T) {
+	t.Run("最新的两个次版本", func(t *testing.T) {
+		assert.Equal(t, []string{"1.22", "1.21"}, supportedLines([]*version.Version{
+			newRemote("1.20.14"),
+			newRemote("1.21.6"),
+			newRemote("1.22.0"),
+			newRemote("1.22.1"),
+			newRemote("1.23rc1"),
+		}))
+	})
+
+	t.Run("无远程版本", func(t *testing.T) {
+		assert.Nil(t, supportedLines(nil))
+	})
+}
+
+func Test_supportStatus(t *testing.T) {
+	supported := []string{"1.22", "1.21"}
+	tests := []struct {
+		vname     string
+		supported []string
+		want      string
+	}{
+		{vname: "1.22.1", supported: supported, want: supportSupported},
+		{vname: "1.21.0", supported: supported, want: supportSupported},
+		{vname: "1.20.14", supported: supported, want: supportUnsupported},
+		{vname: "1.22rc1", supported: supported, want: supportUnsupported},
+		{vname: "1.23rc1", supported: supported, want: supportPrerelease},
+		{vname: "1.22.1", supported: nil, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.vname, func(t *testing.T) {
+			assert.Equal(t, tt.want, supportStatus(version.MustNew(tt.vname), tt.supported))
+		})
+	}
+}
+
+func Test_renderVersions_support(t *testing.T) {
+	items := []*version.Version{
+		version.MustNew("1.20.14"),
+		version.MustNew("1.21.10"),
+		version.MustNew("1.22.1"),
+	}
+
+	t.Run("渲染维护状态列", func(t *testing.T) {
+		var got strings.Builder
+		render(textMode, map[string]bool{"1.22.1": true}, []string{"1.22", "1.21"}, items, &got)
+		assert.Equal(t, "  1.20.14   unsupported\n  1.21.10   supported\n* 1.22.1    supported\n", got.String())
+	})
+
+	t.Run("筛选已停止维护的版本", func(t *testing.T) {
+		unsupported := filterUnsupported(items, []string{"1.22", "1.21"})
+		assert.Equal(t, 1, len(unsupported))
+		assert.Equal(t, "1.20.14", unsupported[0].Name())
+	})
+}
+
+func Test_cachedSupportedLines(t *testing.T) {
+	home := ghomeDir
+	t.Cleanup(func() { ghomeDir = home })
+	ghomeDir = t.TempDir()
+
+	t.Run("缓存不存在", func(t *testing.T) {
+		lines, fresh := cachedSupportedLines()
+		assert.Nil(t, lines)
+		assert.False(t, fresh)
+	})
+
+	t.Run("读取有效期内的缓存", func(t *testing.T) {
+		writeSupportCache([]string{"1.22", "1.21"})
+		lines, fresh := cachedSupportedLines()
+		assert.Equal(t, []string{"1.22", "1.21"}, lines)
+		assert.True(t, fresh)
+	})
+
+	t.Run("读取过期的缓存", func(t *testing.T) {
+		data, err := json.Marshal(supportCache{UpdatedAt: time.Now().Add(-2 * supportCacheTTL), Lines: []string{"1.21", "1.20"}})
+		assert.Nil(t, err)
+		assert.Nil(t, os.WriteFile(supportFile(), data, 0644))
+
+		lines, fresh := cachedSupportedLines()
+		assert.Equal(t, []string{"1.21", "1.20"}, lines)
+		assert.False(t, fresh)
+	})
+}
@@ -0,0 +1,181 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v2"
+	"github.com/voidint/g/version"
+)
+
+// 版本列表的排序方式
+const (
+	sortAsc  = "asc"
+	sortDesc = "desc"
+)
+
+// validateListFlag 检查ls、ls-remote命令的输出格式及列表选项
+func validateListFlag(ctx *cli.Context) error {
+	switch out := ctx.String("output"); out {
+	case "", "text", "json", "wide", "table":
+	default:
+		return cli.Exit(errstring(fmt.Errorf("unable to match a printer suitable for the output format %q, allowed formats are: [text|json|wide|table]", out)), 1)
+	}
+	if s := ctx.String("sort"); s != "" && s != sortAsc && s != sortDesc {
+		return cli.Exit(errstring(fmt.Errorf("invalid value %q for --sort, allowed values are: [asc|desc]", s)), 1)
+	}
+	switch kind := strings.ToLower(ctx.String("kind")); kind {
+	case "", strings.ToLower(string(version.ArchiveKind)), strings.ToLower(string(version.SourceKind)), strings.ToLower(string(version.InstallerKind)):
+	default:
+		return cli.Exit(errstring(fmt.Errorf("invalid value %q for --kind, allowed values are: [archive|source|installer]", kind)), 1)
+	}
+	if ctx.Int("limit") < 0 {
+		return cli.Exit(errstring(fmt.Errorf("invalid value %d for --limit, it must not be negative", ctx.Int("limit"))), 1)
+	}
+	return nil
+}
+
+// outputMode 返回--output选项对应的渲染模式
+func outputMode(ctx *cli.Context) uint8 {
+	switch ctx.String("output") {
+	case "json":
+		return jsonMode
+	case "wide":
+		return wideMode
+	case "table":
+		return tableMode
+	default:
+		return textMode
+	}
+}
+
+// listVersions 按ls、ls-remote命令的--latest-per-minor、--sort、--limit选项处理版本列表
+func listVersions(ctx *cli.Context, items []*version.Version) []*version.Version {
+	return shapeVersions(items, ctx.Bool("latest-per-minor"), ctx.String("sort") == sortDesc, ctx.Int("limit"))
+}
+
+// shapeVersions 依次对版本列表做次版本合并、排序及数量截取，返回新的列表。limit为0时不限制数量。
+func shapeVersions(items []*version.Version, latestPerMinor, desc bool, limit int) []*version.Version {
+	items = append([]*version.Version(nil), items...)
+	sort.Stable(version.Collection(items))
+
+	if latestPerMinor {
+		items = version.LatestPerMinor(items)
+	}
+	if desc {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return items
+}
+
+// versionRow wide、table模式下版本列表的一行
+type versionRow struct {
+	Version   string
+	Channel   string // 版本通道：stable（正式版本）、unstable（预发布版本）、archived（已停止维护的正式版本）。
+	Installed string // 安装状态：in use、yes或空。
+	Size      string // 本机平台压缩包的大小
+	Host      string // 是否存在适用于本机平台的安装包
+}
+
+var versionRowHeader = []string{"VERSION", "CHANNEL", "INSTALLED", "SIZE", "HOST"}
+
+func (row versionRow) cells() []string {
+	return []string{row.Version, row.Channel, row.Installed, row.Size, row.Host}
+}
+
+// newVersionRow 返回版本对应的行。大小及可用性依据适用于本机操作系统及该行硬件架构（默认为本机硬件架构）的安装包。
+func newVersionRow(vo versionOut) versionRow {
+	row := versionRow{
+		Version: vo.dirName(),
+		Channel: versionChannel(vo),
+		Size:    "-",
+		Host:    "no",
+	}
+	goarch := vo.Arch
+	if goarch == "" {
+		goarch = runtime.GOARCH
+	}
+	if vo.InUse {
+		row.Installed = "in use"
+	} else if vo.Installed {
+		row.Installed = "yes"
+	}
+
+	for i := range vo.Packages {
+		pkg := &vo.Packages[i]
+		if pkg.Match(version.SourceKind, "", "") || !pkg.Match("", runtime.GOOS, goarch) {
+			continue
+		}
+		row.Host = "yes"
+		if pkg.Match(version.ArchiveKind, "", "") && pkg.Size != "" {
+			row.Size = pkg.Size
+		}
+	}
+	return row
+}
+
+// versionChannel 返回版本所属的通道。预发布版本为unstable，正式版本依据维护状态区分stable与archived，维护状态未知时视为stable。
+func versionChannel(vo versionOut) string {
+	if sv, err := version.Semantify(vo.Version); err == nil && sv.Prerelease() != "" {
+		return unstableChannel
+	}
+	if vo.Support == supportUnsupported {
+		return archivedChannel
+	}
+	return stableChannel
+}
+
+// renderVersionTable 以对齐的列（wide）或带边框的表格（table）渲染版本列表
+func renderVersionTable(mode uint8, vs []versionOut, out io.Writer) {
+	rows := make([][]string, 0, len(vs)+1)
+	rows = append(rows, versionRowHeader)
+	for _, vo := range vs {
+		rows = append(rows, newVersionRow(vo).cells())
+	}
+
+	if mode == wideMode {
+		w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+		for _, cells := range rows {
+			_, _ = fmt.Fprintln(w, strings.Join(cells, "\t"))
+		}
+		_ = w.Flush()
+		return
+	}
+
+	widths := make([]int, len(versionRowHeader))
+	for _, cells := range rows {
+		for i, cell := range cells {
+			if len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+	}
+	var border strings.Builder
+	border.WriteString("+")
+	for _, width := range widths {
+		border.WriteString(strings.Repeat("-", width+2) + "+")
+	}
+
+	_, _ = fmt.Fprintln(out, border.String())
+	for i, cells := range rows {
+		var line strings.Builder
+		line.WriteString("|")
+		for j, cell := range cells {
+			line.WriteString(fmt.Sprintf(" %-*s |", widths[j], cell))
+		}
+		_, _ = fmt.Fprintln(out, line.String())
+		if i == 0 {
+			_, _ = fmt.Fprintln(out, border.String())
+		}
+	}
+	_, _ = fmt.Fprintln(out, border.String())
+}
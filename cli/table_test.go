@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/version"
+)
+
+func Test_shapeVersions(t *testing.T) {
+	items := []*version.Version{
+		version.MustNew("1.22.1"),
+		version.MustNew("1.20.14"),
+		version.MustNew("1.21.6"),
+		version.MustNew("1.21.5"),
+		version.MustNew("1.22.0"),
+	}
+	names := func(vs []*version.Version) []string {
+		out := make([]string, 0, len(vs))
+		for _, v := range vs {
+			out = append(out, v.Name())
+		}
+		return out
+	}
+
+	tests := []struct {
+		name           string
+		latestPerMinor bool
+		desc           bool
+		limit          int
+		want           []string
+	}{
+		{name: "升序", want: []string{"1.20.14", "1.21.5", "1.21.6", "1.22.0", "1.22.1"}},
+		{name: "降序", desc: true, want: []string{"1.22.1", "1.22.0", "1.21.6", "1.21.5", "1.20.14"}},
+		{name: "每个次版本仅保留最新的修订版本", latestPerMinor: true, want: []string{"1.20.14", "1.21.6", "1.22.1"}},
+		{name: "降序并限制数量", latestPerMinor: true, desc: true, limit: 2, want: []string{"1.22.1", "1.21.6"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, names(shapeVersions(items, tt.latestPerMinor, tt.desc, tt.limit)))
+		})
+	}
+
+	t.Run("不修改原列表", func(t *testing.T) {
+		_ = shapeVersions(items, false, true, 1)
+		assert.Equal(t, "1.22.1", items[0].Name())
+		assert.Equal(t, 5, len(items))
+	})
+}
+
+func Test_renderVersionTable(t *testing.T) {
+	vs := []versionOut{
+		{
+			Version: "1.21.6",
+			Support: supportSupported,
+			Packages: []version.Package{
+				{Kind: version.SourceKind, Size: "26MB"},
+				{Kind: version.ArchiveKind, GOOS: runtime.GOOS, GOARCH: runtime.GOARCH, Size: "66MB"},
+			},
+		},
+		{
+			Version:   "1.22.1",
+			Support:   supportSupported,
+			Installed: true,
+			InUse:     true,
+			Packages: []version.Package{
+				{Kind: version.ArchiveKind, GOOS: runtime.GOOS, GOARCH: runtime.GOARCH + "x", Size: "67MB"},
+			},
+		},
+		{Version: "1.23rc1", Support: supportPrerelease, Installed: true},
+	}
+
+	t.Run("wide", func(t *testing.T) {
+		var got strings.Builder
+		renderVersionTable(wideMode, vs, &got)
+		assert.Equal(t, `VERSION   CHANNEL    INSTALLED   SIZE   HOST
+1.21.6    stable                 66MB   yes
+1.22.1    stable     in use      -      no
+1.23rc1   unstable   yes         -      no
+`, got.String())
+	})
+
+	t.Run("table", func(t *testing.T) {
+		var got strings.Builder
+		renderVersionTable(tableMode, vs[:1], &got)
+		assert.Equal(t, `+---------+---------+-----------+------+------+
+| VERSION | CHANNEL | INSTALLED | SIZE | HOST |
++---------+---------+-----------+------+------+
+| 1.21.6  | stable  |           | 66MB | yes  |
++---------+---------+-----------+------+------+
+`, got.String())
+	})
+}
+
+func Test_newVersionRow(t *testing.T) {
+	goarch := "386"
+	if runtime.GOARCH == goarch {
+		goarch = "amd64"
+	}
+	pkgs := []version.Package{
+		{Kind: version.ArchiveKind, GOOS: runtime.GOOS, GOARCH: runtime.GOARCH, Size: "66MB"},
+		{Kind: version.ArchiveKind, GOOS: runtime.GOOS, GOARCH: goarch, Size: "60MB"},
+	}
+
+	tests := []struct {
+		name    string
+		vo      versionOut
+		channel string
+		size    string
+	}{
+		{name: "维护状态未知的正式版本", vo: versionOut{Version: "1.22.3"}, channel: stableChannel, size: "-"},
+		{name: "已停止维护的正式版本", vo: versionOut{Version: "1.20.14", Support: supportUnsupported}, channel: archivedChannel, size: "-"},
+		{name: "维护中次版本的预发布版本", vo: versionOut{Version: "1.22rc1", Support: supportUnsupported}, channel: unstableChannel, size: "-"},
+		{name: "本机硬件架构的安装包", vo: versionOut{Version: "1.22.3", Packages: pkgs}, channel: stableChannel, size: "66MB"},
+		{name: "非本机硬件架构的安装包", vo: versionOut{Version: "1.22.3", Arch: goarch, Packages: pkgs}, channel: stableChannel, size: "60MB"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row := newVersionRow(tt.vo)
+			assert.Equal(t, tt.channel, row.Channel)
+			assert.Equal(t, tt.size, row.Size)
+		})
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/dixonwille/wmenu/v5"
 	"github.com/urfave/cli/v2"
 )
 
@@ -13,15 +15,62 @@ func uninstall(ctx *cli.Context) (err error) {
 	if vname == "" {
 		return cli.ShowSubcommandHelp(ctx)
 	}
-	targetV := filepath.Join(versionsDir, vname)
 
-	if finfo, err := os.Stat(targetV); err != nil || !finfo.IsDir() {
-		return cli.Exit(fmt.Sprintf("[g] %q version is not installed", vname), 1)
+	dirNames, err := uninstallTargets(vname, ctx.String("arch"))
+	if err != nil {
+		return cli.Exit(wrapstring(fmt.Sprintf("%q version is not installed: %s", vname, err.Error())), 1)
+	}
+	if len(dirNames) > 1 {
+		if ok, err := confirmUninstall(ctx, dirNames); err != nil || !ok {
+			return err
+		}
 	}
 
-	if err = os.RemoveAll(targetV); err != nil {
-		return cli.Exit(wrapstring(fmt.Sprintf("Uninstall failed: %s", err.Error())), 1)
+	for _, dirName := range dirNames {
+		if err = os.RemoveAll(filepath.Join(versionsDir, dirName)); err != nil {
+			return cli.Exit(wrapstring(fmt.Sprintf("Uninstall failed: %s", err.Error())), 1)
+		}
+		appendHistory(historyUninstall, dirName, "")
+		fmt.Printf("Uninstalled go%s\n", dirName)
 	}
-	fmt.Printf("Uninstalled go%s\n", vname)
 	return nil
 }
+
+// uninstallTargets 返回待卸载的版本目录名。参数为版本约束时（如'<1.20'），返回全部满足约束的已安装版本。
+func uninstallTargets(vname, goarch string) (dirNames []string, err error) {
+	vname, _ = expandAlias(vname)
+	dirName := installedDirName(vname, goarch)
+	if finfo, err := os.Stat(filepath.Join(versionsDir, dirName)); err == nil && finfo.IsDir() {
+		return []string{dirName}, nil
+	}
+
+	items, err := installedFinder(goarch).FindAll(vname)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range items {
+		dirNames = append(dirNames, installedDirName(v.Name(), goarch))
+	}
+	return dirNames, nil
+}
+
+// confirmUninstall 卸载多个版本前请求用户确认
+func confirmUninstall(ctx *cli.Context, dirNames []string) (ok bool, err error) {
+	if ctx.Bool("yes") {
+		return true, nil
+	}
+	if !interactive() {
+		return false, cli.Exit(wrapstring(fmt.Sprintf("%d versions match, use --yes to uninstall all of them: %s", len(dirNames), strings.Join(dirNames, ", "))), 1)
+	}
+
+	menu := wmenu.NewMenu(fmt.Sprintf("Uninstall %s?", strings.Join(dirNames, ", ")))
+	menu.IsYesNo(wmenu.DefN)
+	menu.Action(func(opts []wmenu.Opt) error {
+		ok = opts[0].Value.(string) == "yes"
+		return nil
+	})
+	if err = menu.Run(); err != nil {
+		return false, cli.Exit(errstring(err), 1)
+	}
+	return ok, nil
+}
@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/k0kubun/go-ansi"
+	"github.com/urfave/cli/v2"
+	"github.com/voidint/g/version"
+)
+
+// upgradeItem 某个次版本的升级计划及结果
+type upgradeItem struct {
+	Line     string   `json:"line"`
+	Arch     string   `json:"arch"`
+	From     string   `json:"from"`
+	To       string   `json:"to"`
+	InUse    bool     `json:"inUse"`
+	Pruned   []string `json:"pruned,omitempty"`
+	Upgraded bool     `json:"upgraded"`
+
+	target     *version.Version
+	superseded []string // 该次版本中已安装的旧版本目录名
+}
+
+func upgrade(ctx *cli.Context) (err error) {
+	// JSON模式下将下载进度等信息输出至标准错误，以保证标准输出仅包含JSON。
+	var progress io.Writer = ansi.NewAnsiStdout()
+	jsonOutput := ctx.String("output") == "json"
+	if jsonOutput {
+		if !ctx.Bool("yes") && !ctx.Bool("dry-run") {
+			// 交互式菜单会污染标准输出，因此JSON模式下不允许弹出菜单。
+			return cli.Exit(wrapstring("-o json requires --yes (or --dry-run) because interactive prompts would corrupt the JSON output"), 1)
+		}
+		progress = ansi.NewAnsiStderr()
+	}
+
+	installed := installedVersions()
+	if vname := ctx.Args().First(); vname != "" && len(installed) > 0 {
+		target, _ := expandAlias(vname)
+		if installed, err = version.NewFinder(installed, version.WithFinderGoarch("")).FindAll(target); err != nil {
+			return cli.Exit(errstring(err), 1)
+		}
+	}
+
+	remote, err := remoteVersions()
+	if err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	writeSupportCache(supportedLines(remote))
+
+	items := planUpgrades(installed, remote)
+	inused := inuse(goroot)
+	for i := range items {
+		for _, dirName := range items[i].superseded {
+			items[i].InUse = items[i].InUse || dirName == inused
+		}
+	}
+
+	if !ctx.Bool("dry-run") {
+		for i := range items {
+			if err = applyUpgrade(ctx, &items[i], progress); err != nil {
+				return err
+			}
+		}
+	}
+
+	if jsonOutput {
+		if items == nil {
+			items = []upgradeItem{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "    ")
+		_ = enc.Encode(items)
+		return nil
+	}
+	renderUpgrades(items, ctx.Bool("dry-run"))
+	return nil
+}
+
+// planUpgrades 返回各已安装次版本（按硬件架构区分）升级至最新修订版本的计划。installed与remote须按升序排列。
+func planUpgrades(installed, remote []*version.Version) (items []upgradeItem) {
+	type lineKey struct{ line, arch string }
+
+	var keys []lineKey
+	groups := make(map[lineKey][]*version.Version)
+	for _, v := range installed {
+		goarch := installedArch(v)
+		key := lineKey{line: minorLine(v), arch: goarch}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], v)
+	}
+
+	for _, key := range keys {
+		group := groups[key]
+		current := group[len(group)-1]
+
+		latest, err := latestPatch(version.NewFinder(remote,
+			version.WithFinderGoos(runtime.GOOS),
+			version.WithFinderGoarch(key.arch),
+		), current)
+		if err != nil || !(version.Collection{current, latest}).Less(0, 1) {
+			continue // 已是最新修订版本，或远程没有该次版本的稳定版本。
+		}
+
+		item := upgradeItem{
+			Line:   key.line,
+			Arch:   key.arch,
+			From:   current.Name(),
+			To:     latest.Name(),
+			target: latest,
+		}
+		for _, v := range group {
+			item.superseded = append(item.superseded, installedDirName(v.Name(), key.arch))
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// applyUpgrade 安装次版本的最新修订版本，下载进度等信息输出至progress。若旧版本正在使用中，则切换至新版本；指定了--prune时删除旧版本。
+func applyUpgrade(ctx *cli.Context, item *upgradeItem, progress io.Writer) (err error) {
+	dirName, err := installVersion(ctx, item.target, item.Arch, progress)
+	if err != nil || dirName == "" {
+		return err
+	}
+	item.Upgraded = true
+
+	if item.InUse {
+		previous := inuse(goroot)
+		_ = os.Remove(goroot)
+		if err = mkSymlink(filepath.Join(versionsDir, dirName), goroot); err != nil {
+			return cli.Exit(errstring(err), 1)
+		}
+		appendHistory(historyUse, dirName, previous)
+	}
+
+	if ctx.Bool("prune") {
+		for _, old := range item.superseded {
+			if err = os.RemoveAll(filepath.Join(versionsDir, old)); err != nil {
+				return cli.Exit(wrapstring(fmt.Sprintf("Uninstall failed: %s", err.Error())), 1)
+			}
+			appendHistory(historyUninstall, old, "")
+			item.Pruned = append(item.Pruned, old)
+		}
+	}
+	return nil
+}
+
+func renderUpgrades(items []upgradeItem, dryRun bool) {
+	if len(items) == 0 {
+		fmt.Println("All installed versions are up to date")
+		return
+	}
+	if dryRun {
+		fmt.Println("The following versions would be upgraded:")
+	}
+	for _, item := range items {
+		line := fmt.Sprintf("  %s: %s -> %s", installedDirName(item.Line, item.Arch), item.From, item.To)
+		if item.InUse {
+			line += " (in use)"
+		}
+		if len(item.Pruned) > 0 {
+			line += fmt.Sprintf(", removed %d old version(s)", len(item.Pruned))
+		}
+		fmt.Println(line)
+	}
+}
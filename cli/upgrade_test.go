@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/version"
+)
+
+func Test_planUpgrades(t *testing.T) {
+	remote := []*version.Version{
+		newRemote("1.20.14"),
+		newRemote("1.21.5"),
+		newRemote("1.21.6"),
+		newRemote("1.22rc1"),
+		newRemote("1.22.0"),
+	}
+
+	t.Run("升级至各次版本的最新修订版本", func(t *testing.T) {
+		items := planUpgrades([]*version.Version{
+			newInstalled("1.20.14"),
+			newInstalled("1.21.4"),
+			newInstalled("1.21.5"),
+			newInstalled("1.22rc1"),
+		}, remote)
+
+		assert.Equal(t, 2, len(items))
+		assert.Equal(t, "1.21", items[0].Line)
+		assert.Equal(t, "1.21.5", items[0].From)
+		assert.Equal(t, "1.21.6", items[0].To)
+		assert.Equal(t, []string{"1.21.4", "1.21.5"}, items[0].superseded)
+		assert.Equal(t, "1.22rc1", items[1].From)
+		assert.Equal(t, "1.22.0", items[1].To)
+	})
+
+	t.Run("已是最新修订版本", func(t *testing.T) {
+		assert.Empty(t, planUpgrades([]*version.Version{newInstalled("1.21.6")}, remote))
+	})
+}
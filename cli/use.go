@@ -5,14 +5,46 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 
+	"github.com/fatih/color"
 	"github.com/urfave/cli/v2"
+	"github.com/voidint/g/version"
 )
 
 func use(ctx *cli.Context) (err error) {
 	vname := ctx.Args().First()
-	if vname == "" {
-		return cli.ShowSubcommandHelp(ctx)
+	if ctx.Bool("from-gomod") && vname != "" {
+		return cli.Exit(wrapstring("the --from-gomod flag cannot be used together with a version argument"), 1)
+	}
+	if vname == "-" {
+		// 切换回上一个使用的版本
+		records, err := readHistory(historyFile())
+		if err != nil {
+			return cli.Exit(errstring(err), 1)
+		}
+		if vname, err = previousVersion(records); err != nil {
+			return cli.Exit(errstring(err), 1)
+		}
+	} else if vname == "" || ctx.Bool("from-gomod") {
+		// 未指定版本时使用项目版本文件（或go.mod）中指定的版本
+		var filename, pv string
+		if ctx.Bool("from-gomod") {
+			filename, pv, err = gomodVersion()
+		} else {
+			filename, pv, err = projectVersion()
+		}
+		if err != nil {
+			return cli.Exit(errstring(err), 1)
+		}
+		if pv == "" {
+			return cli.ShowSubcommandHelp(ctx)
+		}
+		if vname, err = resolveInstalled(pv, ctx.String("arch")); err != nil {
+			return cli.Exit(wrapstring(fmt.Sprintf("the %q version required by %s is not installed, please install it first: %s", pv, filename, err.Error())), 1)
+		}
+	} else if vname, err = installedTarget(vname, ctx.String("arch")); err != nil {
+		return cli.Exit(wrapstring(fmt.Sprintf("the %q version does not exist, please install it first: %s", ctx.Args().First(), err.Error())), 1)
 	}
 	targetV := filepath.Join(versionsDir, vname)
 
@@ -20,13 +52,31 @@ func use(ctx *cli.Context) (err error) {
 		return cli.Exit(fmt.Sprintf("[g] The %q version does not exist, please install it first.", vname), 1)
 	}
 
+	previous := inuse(goroot)
 	_ = os.Remove(goroot)
 
 	if err = mkSymlink(targetV, goroot); err != nil {
 		return cli.Exit(errstring(err), 1)
 	}
+	appendHistory(historyUse, vname, previous)
 	if output, err := exec.Command(filepath.Join(goroot, "bin", "go"), "version").Output(); err == nil {
 		fmt.Print(string(output))
 	}
+	warnUnsupported(vname)
 	return nil
 }
+
+// warnUnsupported 所切换的版本已停止维护时输出警告。维护状态仅来自缓存，未知时不输出。
+func warnUnsupported(dirName string) {
+	vname, _ := parseInstalledDirName(dirName)
+	v, err := version.New(vname)
+	if err != nil {
+		return
+	}
+	supported, _ := cachedSupportedLines()
+	if supportStatus(v, supported) != supportUnsupported {
+		return
+	}
+	_, _ = color.New(color.FgYellow).Fprintf(os.Stderr, "[g] Warning: go%s no longer receives security fixes, the supported versions are %s.\n",
+		vname, strings.Join(supported, " and "))
+}
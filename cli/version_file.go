@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/voidint/g/pkg/modfile"
+	"github.com/voidint/g/version"
+)
+
+const (
+	// goVersionFile 项目版本文件，内容为版本号或版本约束，如'1.21.4'、'~1.21'。
+	goVersionFile = ".go-version"
+	// toolVersionsFile asdf的项目版本文件，其中以golang开头的行记录了go版本号，如'golang 1.21.4'。
+	toolVersionsFile = ".tool-versions"
+)
+
+// versionFileRoot 向上查找项目版本文件时的最上层目录，为空时查找至文件系统的根目录。
+var versionFileRoot string
+
+// findVersionFile 自dir起逐级向上查找项目版本文件，返回版本文件路径及其中记录的版本。未找到时返回空字符串。
+func findVersionFile(dir string) (filename, vname string, err error) {
+	if dir, err = filepath.Abs(dir); err != nil {
+		return "", "", err
+	}
+
+	for {
+		for _, parse := range []struct {
+			name string
+			fn   func(line string) string
+		}{
+			{name: goVersionFile, fn: parseGoVersionLine},
+			{name: toolVersionsFile, fn: parseToolVersionsLine},
+		} {
+			filename = filepath.Join(dir, parse.name)
+			if vname, err = readVersionFile(filename, parse.fn); err != nil {
+				return "", "", err
+			}
+			if vname != "" {
+				return filename, vname, nil
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir || dir == versionFileRoot {
+			return "", "", nil
+		}
+		dir = parent
+	}
+}
+
+// readVersionFile 返回版本文件中第一个有效的版本。文件不存在时返回空字符串。
+func readVersionFile(filename string, parse func(line string) string) (vname string, err error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = strings.TrimSpace(line[:idx])
+		}
+		if line == "" {
+			continue
+		}
+		if vname = parse(line); vname != "" {
+			return vname, nil
+		}
+	}
+	return "", scanner.Err()
+}
+
+func parseGoVersionLine(line string) string {
+	return trimGoPrefix(line)
+}
+
+func parseToolVersionsLine(line string) string {
+	fields := strings.Fields(line)
+	if len(fields) < 2 || fields[0] != "golang" {
+		return ""
+	}
+	return trimGoPrefix(fields[1])
+}
+
+// trimGoPrefix 去除版本号的go前缀，如'go1.21.4'将返回'1.21.4'。
+func trimGoPrefix(vname string) string {
+	if trimmed := strings.TrimPrefix(vname, "go"); trimmed != "" && unicode.IsDigit(rune(trimmed[0])) {
+		return trimmed
+	}
+	return vname
+}
+
+// projectVersion 返回当前目录所属项目通过版本文件指定的版本
+func projectVersion() (filename, vname string, err error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", "", err
+	}
+	return findVersionFile(wd)
+}
+
+// gomodVersion 返回当前目录所属模块（工作区）的go.mod（go.work）所要求的版本：toolchain指令为具体版本，go指令为最低版本（如'>= 1.21.0'）。
+// 未找到go.mod及go.work时返回错误。
+func gomodVersion() (filename, vname string, err error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", "", err
+	}
+	if filename, err = modfile.Find(wd); err != nil {
+		return "", "", err
+	}
+	if filename == "" {
+		return "", "", fmt.Errorf("no %s or %s found in %s or any parent directory", modfile.GoModFile, modfile.GoWorkFile, wd)
+	}
+	f, err := modfile.ParseFile(filename)
+	if err != nil {
+		return "", "", err
+	}
+	if vname, err = version.ToolchainVersion(f.Go, f.Toolchain); err != nil {
+		return "", "", fmt.Errorf("no valid go or toolchain directive in %s", filename)
+	}
+	return filename, vname, nil
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_findVersionFile(t *testing.T) {
+	rootDir := t.TempDir()
+	// 查找不越过临时目录，避免其上级目录中的版本文件影响结果。
+	oldRoot := versionFileRoot
+	t.Cleanup(func() { versionFileRoot = oldRoot })
+	versionFileRoot = rootDir
+	projectDir := filepath.Join(rootDir, "project")
+	subDir := filepath.Join(projectDir, "cmd", "app")
+	assert.Nil(t, os.MkdirAll(subDir, 0755))
+
+	t.Run("版本文件不存在", func(t *testing.T) {
+		filename, vname, err := findVersionFile(subDir)
+		assert.Nil(t, err)
+		assert.Equal(t, "", filename)
+		assert.Equal(t, "", vname)
+	})
+
+	t.Run("向上查找.tool-versions", func(t *testing.T) {
+		filename := filepath.Join(rootDir, toolVersionsFile)
+		assert.Nil(t, os.WriteFile(filename, []byte("nodejs 20.11.0\n# comment\ngolang 1.21.4\n"), 0644))
+
+		gotFilename, vname, err := findVersionFile(subDir)
+		assert.Nil(t, err)
+		assert.Equal(t, filename, gotFilename)
+		assert.Equal(t, "1.21.4", vname)
+	})
+
+	t.Run("就近的.go-version优先", func(t *testing.T) {
+		filename := filepath.Join(projectDir, goVersionFile)
+		assert.Nil(t, os.WriteFile(filename, []byte("\n~1.21 # comment\n"), 0644))
+
+		gotFilename, vname, err := findVersionFile(subDir)
+		assert.Nil(t, err)
+		assert.Equal(t, filename, gotFilename)
+		assert.Equal(t, "~1.21", vname)
+	})
+
+	t.Run("不含golang的.tool-versions被忽略", func(t *testing.T) {
+		assert.Nil(t, os.WriteFile(filepath.Join(subDir, toolVersionsFile), []byte("nodejs 20.11.0\n"), 0644))
+
+		gotFilename, vname, err := findVersionFile(subDir)
+		assert.Nil(t, err)
+		assert.Equal(t, filepath.Join(projectDir, goVersionFile), gotFilename)
+		assert.Equal(t, "~1.21", vname)
+	})
+}
+
+func Test_parseToolVersionsLine(t *testing.T) {
+	assert.Equal(t, "1.21.4", parseToolVersionsLine("golang 1.21.4"))
+	assert.Equal(t, "1.21.4", parseToolVersionsLine("golang go1.21.4"))
+	assert.Equal(t, "", parseToolVersionsLine("nodejs 20.11.0"))
+	assert.Equal(t, "", parseToolVersionsLine("golang"))
+}
+
+func Test_parseGoVersionLine(t *testing.T) {
+	assert.Equal(t, "1.21.4", parseGoVersionLine("1.21.4"))
+	assert.Equal(t, "1.21.4", parseGoVersionLine("go1.21.4"))
+	assert.Equal(t, "~1.21", parseGoVersionLine("~1.21"))
+	assert.Equal(t, "latest", parseGoVersionLine("latest"))
+	assert.Equal(t, "1.18 - 1.20", parseGoVersionLine("1.18 - 1.20"))
+}
+
+func Test_gomodVersion(t *testing.T) {
+	wd, err := os.Getwd()
+	assert.Nil(t, err)
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	rootDir := t.TempDir()
+	subDir := filepath.Join(rootDir, "cmd", "app")
+	assert.Nil(t, os.MkdirAll(subDir, 0755))
+	assert.Nil(t, os.Chdir(subDir))
+
+	t.Run("go指令为最低版本", func(t *testing.T) {
+		filename := filepath.Join(rootDir, "go.mod")
+		assert.Nil(t, os.WriteFile(filename, []byte("module example.com/app\n\ngo 1.21\n"), 0644))
+
+		gotFilename, vname, err := gomodVersion()
+		assert.Nil(t, err)
+		assert.Equal(t, filename, gotFilename)
+		assert.Equal(t, ">= 1.21.0", vname)
+	})
+
+	t.Run("toolchain指令为具体版本", func(t *testing.T) {
+		filename := filepath.Join(rootDir, "go.mod")
+		assert.Nil(t, os.WriteFile(filename, []byte("module example.com/app\n\ngo 1.21\n\ntoolchain go1.22.3\n"), 0644))
+
+		_, vname, err := gomodVersion()
+		assert.Nil(t, err)
+		assert.Equal(t, "1.22.3", vname)
+	})
+}
@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+	"github.com/voidint/g/version"
+)
+
+// wrapperMarker 版本启动器中的标记行，用于区分由g生成的启动器与用户自行放置的文件。
+const wrapperMarker = "generated by g"
+
+// wrappersDir 返回版本启动器所在目录，即g自身所在的~/.g/bin。
+func wrappersDir() string {
+	return filepath.Join(ghomeDir, "bin")
+}
+
+func syncWrappersCmd(ctx *cli.Context) (err error) {
+	if err = syncWrappers(wrappersDir()); err != nil {
+		return cli.Exit(errstring(err), 1)
+	}
+	return nil
+}
+
+// syncWrappers 依据已安装的版本重新生成版本启动器，并删除失效的启动器。
+func syncWrappers(dir string) (err error) {
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	targets := wrapperTargets(installedVersions())
+
+	// 删除已失效的启动器
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), wrapperExt())
+		if _, ok := targets[name]; ok || entry.IsDir() || !isWrapper(filepath.Join(dir, entry.Name())) {
+			continue
+		}
+		if err = os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+			return err
+		}
+		fmt.Printf("Removed %s\n", name)
+	}
+
+	names := make([]string, 0, len(targets))
+	for name := range targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		filename := filepath.Join(dir, name+wrapperExt())
+		if _, err = os.Stat(filename); err == nil && !isWrapper(filename) {
+			fmt.Fprintf(os.Stderr, "[g] Skipped %s: the file was not generated by g\n", filename)
+			continue
+		}
+		script := wrapperScript(runtime.GOOS, filepath.Join(versionsDir, targets[name]))
+		if err = os.WriteFile(filename, []byte(script), 0755); err != nil {
+			return err
+		}
+		fmt.Printf("%s -> go%s\n", name, targets[name])
+	}
+	return nil
+}
+
+// wrapperTargets 返回启动器名称与已安装版本目录名的映射。
+// 每个已安装的当前架构版本对应一个启动器（如'go1.21.6'），每个次版本另有一个指向其最新稳定版本的启动器（如'go1.21'）。
+func wrapperTargets(items []*version.Version) map[string]string {
+	targets := make(map[string]string, len(items))
+	for _, v := range items { // 升序
+		if pkgs := v.Packages(); len(pkgs) > 0 && pkgs[0].GOARCH != runtime.GOARCH {
+			continue // 跳过其他架构的版本
+		}
+		targets["go"+v.Name()] = v.Name()
+
+		if sv, err := version.Semantify(v.Name()); err == nil && sv.Prerelease() == "" {
+			targets["go"+minorLine(v)] = v.Name()
+		}
+	}
+	return targets
+}
+
+// wrapperScript 返回以targetV作为GOROOT运行go命令的启动器脚本
+func wrapperScript(goos, targetV string) string {
+	if goos == "windows" {
+		return fmt.Sprintf("@echo off\r\nrem %s, do not edit.\r\nsetlocal\r\nset \"GOROOT=%s\"\r\n\"%s\" %%*\r\n",
+			wrapperMarker, targetV, filepath.Join(targetV, "bin", "go.exe"),
+		)
+	}
+	return fmt.Sprintf("#!/bin/sh\n# %s, do not edit.\nGOROOT=%s exec %s \"$@\"\n",
+		wrapperMarker, quoteShell(bashShell, targetV), quoteShell(bashShell, filepath.Join(targetV, "bin", "go")),
+	)
+}
+
+func wrapperExt() string {
+	if runtime.GOOS == "windows" {
+		return ".cmd"
+	}
+	return ""
+}
+
+// isWrapper 检查文件是否为g生成的版本启动器
+func isWrapper(filename string) bool {
+	f, err := os.Open(filename)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	// 启动器的第二行为标记行，如'# generated by g, do not edit.'
+	scanner := bufio.NewScanner(f)
+	if !scanner.Scan() || !strings.HasPrefix(scanner.Text(), "#!") && !strings.HasPrefix(scanner.Text(), "@echo") {
+		return false
+	}
+	return scanner.Scan() && strings.Contains(scanner.Text(), wrapperMarker)
+}
+
+// minorLine 返回版本所属的次版本，如'1.21.6'将返回'1.21'。
+func minorLine(v *version.Version) string {
+	sv, err := version.Semantify(v.Name())
+	if err != nil {
+		return v.Name()
+	}
+	return fmt.Sprintf("%d.%d", sv.Major(), sv.Minor())
+}
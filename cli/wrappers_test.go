@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/version"
+)
+
+func Test_wrapperTargets(t *testing.T) {
+	otherArch := "386"
+	if runtime.GOARCH == otherArch {
+		otherArch = "amd64"
+	}
+
+	t.Run("生成版本及次版本启动器", func(t *testing.T) {
+		items := []*version.Version{
+			newInstalledArch("1.21.5", runtime.GOARCH),
+			newInstalledArch("1.21.6", runtime.GOARCH),
+			newInstalledArch("1.22.0", otherArch),
+			newInstalledArch("1.23rc1", runtime.GOARCH),
+		}
+		assert.Equal(t, map[string]string{
+			"go1.21.5":  "1.21.5",
+			"go1.21.6":  "1.21.6",
+			"go1.21":    "1.21.6",
+			"go1.23rc1": "1.23rc1",
+		}, wrapperTargets(items))
+	})
+
+	t.Run("未安装任何版本", func(t *testing.T) {
+		assert.Empty(t, wrapperTargets(nil))
+	})
+}
+
+func Test_isWrapper(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("g生成的启动器", func(t *testing.T) {
+		for _, goos := range []string{"linux", "windows"} {
+			filename := filepath.Join(dir, "go1.21.6-"+goos)
+			assert.Nil(t, os.WriteFile(filename, []byte(wrapperScript(goos, "/home/g/.g/versions/1.21.6")), 0755))
+			assert.True(t, isWrapper(filename))
+		}
+	})
+
+	t.Run("用户自行放置的文件", func(t *testing.T) {
+		filename := filepath.Join(dir, "go1.20")
+		assert.Nil(t, os.WriteFile(filename, []byte("#!/bin/sh\nexec /usr/local/go/bin/go \"$@\"\n"), 0755))
+		assert.False(t, isWrapper(filename))
+		assert.False(t, isWrapper(filepath.Join(dir, "not-exist")))
+	})
+}
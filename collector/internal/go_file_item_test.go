@@ -483,6 +483,8 @@ func Test_convert2Versions(t *testing.T) {
 				Kind:        version.ArchiveKind,
 				OS:          "Linux",
 				Arch:        "x86-64",
+				GOOS:        "linux",
+				GOARCH:      "amd64",
 				Size:        "128.5 MB",
 				ChecksumURL: "https://mirrors.aliyun.com/golang/go1.17.1.linux-amd64.tar.gz.sha256",
 				Algorithm:   "SHA256",
@@ -496,6 +498,8 @@ func Test_convert2Versions(t *testing.T) {
 				Kind:        version.ArchiveKind,
 				OS:          "Windows",
 				Arch:        "ARM64",
+				GOOS:        "windows",
+				GOARCH:      "arm64",
 				Size:        "118.0 MB",
 				ChecksumURL: "",
 				Algorithm:   "",
@@ -509,6 +513,8 @@ func Test_convert2Versions(t *testing.T) {
 				Kind:        version.ArchiveKind,
 				OS:          "Linux",
 				Arch:        "x86",
+				GOOS:        "linux",
+				GOARCH:      "386",
 				Size:        "107.6 MB",
 				ChecksumURL: "",
 				Algorithm:   "",
@@ -23,9 +23,10 @@ var (
 
 // PackageNotFoundError 软件包不存在错误
 type PackageNotFoundError struct {
-	kind   string
-	goos   string
-	goarch string
+	kind        string
+	goos        string
+	goarch      string
+	suggestions []string
 }
 
 // IsPackageNotFound 若是软件包不存在错误，则返回true；反之，返回false。
@@ -48,14 +49,15 @@ func NewPackageNotFoundError(kind, goos, goarch string) error {
 
 // Error 返回错误详情
 func (e PackageNotFoundError) Error() string {
-	return fmt.Sprintf("package not found [%s,%s,%s]", e.goos, e.goarch, e.kind)
+	return fmt.Sprintf("package not found [%s,%s,%s]", e.goos, e.goarch, e.kind) + didYouMean(e.suggestions)
 }
 
 // VersionNotFoundError 版本不存在错误
 type VersionNotFoundError struct {
-	version string
-	goos    string
-	goarch  string
+	version     string
+	goos        string
+	goarch      string
+	suggestions []string
 }
 
 // IsVersionNotFound 若是版本不存在错误，返回true；反之，返回false。
@@ -78,7 +80,25 @@ func NewVersionNotFoundError(version, goos, goarch string) error {
 
 // Error 返回错误详情
 func (e VersionNotFoundError) Error() string {
-	return fmt.Sprintf("version not found %q [%s,%s]", e.version, e.goos, e.goarch)
+	return fmt.Sprintf("version not found %q [%s,%s]", e.version, e.goos, e.goarch) + didYouMean(e.suggestions)
+}
+
+// WithSuggestions 为版本或软件包不存在错误附加建议的候选版本，如'1.21.13 (latest 1.21)'。其余错误原样返回。
+func WithSuggestions(err error, suggestions ...string) error {
+	switch e := err.(type) {
+	case *VersionNotFoundError:
+		e.suggestions = suggestions
+	case *PackageNotFoundError:
+		e.suggestions = suggestions
+	}
+	return err
+}
+
+func didYouMean(suggestions []string) string {
+	if len(suggestions) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(", did you mean: %s?", strings.Join(suggestions, ", "))
 }
 
 // Version 返回版本号
@@ -99,3 +99,21 @@ func TestDownloadError(t *testing.T) {
 		assert.Equal(t, fmt.Sprintf("resource(%s) download failed ==> %s", url, core.Error()), e.Error())
 	})
 }
+
+func TestNotFoundErrorSuggestions(t *testing.T) {
+	t.Run("版本号不存在错误附带建议", func(t *testing.T) {
+		err := WithSuggestions(NewVersionNotFoundError("1.21.14", "linux", "amd64"), "1.21.13 (latest 1.21)", "1.21.12")
+		assert.Equal(t, `version not found "1.21.14" [linux,amd64], did you mean: 1.21.13 (latest 1.21), 1.21.12?`, err.Error())
+		assert.True(t, IsVersionNotFound(err))
+	})
+
+	t.Run("软件包不存在错误附带建议", func(t *testing.T) {
+		err := WithSuggestions(NewPackageNotFoundError("Archive", "linux", "riscv64"), "1.21.4 (linux/amd64)")
+		assert.Equal(t, "package not found [linux,riscv64,Archive], did you mean: 1.21.4 (linux/amd64)?", err.Error())
+		assert.True(t, IsPackageNotFound(err))
+	})
+
+	t.Run("其余错误原样返回", func(t *testing.T) {
+		assert.Equal(t, ErrEmptyURL, WithSuggestions(ErrEmptyURL, "1.21.4"))
+	})
+}
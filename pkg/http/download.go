@@ -16,6 +16,15 @@ import (
 
 // Download 下载资源并另存为
 func Download(srcURL string, filename string, flag int, perm fs.FileMode, withProgress bool) (size int64, err error) {
+	var progress io.Writer
+	if withProgress {
+		progress = ansi.NewAnsiStdout()
+	}
+	return DownloadTo(srcURL, filename, flag, perm, progress)
+}
+
+// DownloadTo 下载资源并另存为，下载进度输出至progress。progress为nil时不显示下载进度。
+func DownloadTo(srcURL string, filename string, flag int, perm fs.FileMode, progress io.Writer) (size int64, err error) {
 	req, err := http.NewRequest(http.MethodGet, srcURL, nil)
 	if err != nil {
 		return 0, errs.NewDownloadError(srcURL, err)
@@ -38,7 +47,7 @@ func Download(srcURL string, filename string, flag int, perm fs.FileMode, withPr
 	defer f.Close()
 
 	var dst io.Writer
-	if withProgress {
+	if progress != nil {
 		bar := progressbar.NewOptions64(
 			resp.ContentLength,
 			progressbar.OptionEnableColorCodes(true),
@@ -51,12 +60,12 @@ func Download(srcURL string, filename string, flag int, perm fs.FileMode, withPr
 			}),
 			progressbar.OptionSetWidth(15),
 			progressbar.OptionSetDescription("Downloading"),
-			progressbar.OptionSetWriter(ansi.NewAnsiStdout()),
+			progressbar.OptionSetWriter(progress),
 			progressbar.OptionShowBytes(true),
 			progressbar.OptionThrottle(65*time.Millisecond),
 			progressbar.OptionShowCount(),
 			progressbar.OptionOnCompletion(func() {
-				_, _ = fmt.Fprint(ansi.NewAnsiStdout(), "\n")
+				_, _ = fmt.Fprint(progress, "\n")
 			}),
 			// progressbar.OptionSpinnerType(35),
 			// progressbar.OptionFullWidth(),
@@ -0,0 +1,86 @@
+package modfile
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// GoModFile go模块文件名
+	GoModFile = "go.mod"
+	// GoWorkFile go工作区文件名
+	GoWorkFile = "go.work"
+)
+
+// File go.mod或go.work文件中与go版本相关的指令
+type File struct {
+	// Go go指令中的语言版本，如'1.21'、'1.21.0'。
+	Go string
+	// Toolchain toolchain指令中的工具链名称，如'go1.22.3'。
+	Toolchain string
+}
+
+// Parse 解析go.mod或go.work文件内容中的go指令和toolchain指令
+func Parse(data []byte) (*File, error) {
+	var f File
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if idx := strings.Index(line, "//"); idx >= 0 {
+			line = line[:idx]
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		switch fields[0] {
+		case "go":
+			f.Go = fields[1]
+		case "toolchain":
+			f.Toolchain = fields[1]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// ParseFile 解析go.mod或go.work文件中的go指令和toolchain指令
+func ParseFile(filename string) (*File, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Find 自dir起逐级向上查找go.work或go.mod文件，未找到时返回空字符串。
+// 与go命令一致，位于上级目录的go.work优先于更近的go.mod。
+func Find(dir string) (filename string, err error) {
+	if dir, err = filepath.Abs(dir); err != nil {
+		return "", err
+	}
+	if filename = findUp(dir, GoWorkFile); filename != "" {
+		return filename, nil
+	}
+	return findUp(dir, GoModFile), nil
+}
+
+func findUp(dir, name string) string {
+	for {
+		filename := filepath.Join(dir, name)
+		if finfo, err := os.Stat(filename); err == nil && !finfo.IsDir() {
+			return filename
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
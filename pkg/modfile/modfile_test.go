@@ -0,0 +1,77 @@
+package modfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want *File
+	}{
+		{
+			name: "仅包含go指令",
+			data: "module github.com/voidint/g\n\ngo 1.20\n\nrequire (\n\tgithub.com/urfave/cli/v2 v2.27.3\n)\n",
+			want: &File{Go: "1.20"},
+		},
+		{
+			name: "包含go指令和toolchain指令",
+			data: "module example.com/m\n\ngo 1.21 // language version\n\ntoolchain go1.22.3\n",
+			want: &File{Go: "1.21", Toolchain: "go1.22.3"},
+		},
+		{
+			name: "go.work",
+			data: "go 1.22.0\n\nuse (\n\t./a\n\t./b\n)\n",
+			want: &File{Go: "1.22.0"},
+		},
+		{
+			name: "不包含任何指令",
+			data: "module example.com/m\n",
+			want: &File{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse([]byte(tt.data))
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	rootDir := filepath.Join(os.TempDir(), fmt.Sprintf(".g_%d", time.Now().UnixNano()))
+	defer os.RemoveAll(rootDir)
+
+	subDir := filepath.Join(rootDir, "a", "b")
+	_ = os.MkdirAll(subDir, 0755)
+
+	t.Run("向上查找go.mod", func(t *testing.T) {
+		filename := filepath.Join(rootDir, "a", GoModFile)
+		assert.Nil(t, os.WriteFile(filename, []byte("go 1.21\n"), 0644))
+
+		got, err := Find(subDir)
+		assert.Nil(t, err)
+		assert.Equal(t, filename, got)
+	})
+
+	t.Run("上级目录的go.work优先", func(t *testing.T) {
+		filename := filepath.Join(rootDir, GoWorkFile)
+		assert.Nil(t, os.WriteFile(filename, []byte("go 1.22\n"), 0644))
+
+		got, err := Find(subDir)
+		assert.Nil(t, err)
+		assert.Equal(t, filename, got)
+
+		f, err := ParseFile(got)
+		assert.Nil(t, err)
+		assert.Equal(t, "1.22", f.Go)
+	})
+}
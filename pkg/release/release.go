@@ -0,0 +1,129 @@
+package release
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/voidint/g/pkg/errs"
+	httppkg "github.com/voidint/g/pkg/http"
+	"github.com/voidint/g/version"
+)
+
+// DefaultURL 官方发布历史页面地址
+const DefaultURL = "https://go.dev/doc/devel/release"
+
+// Release 发布历史页面中的单次发布
+type Release struct {
+	Version  string `json:"version"`  // 版本号，如'1.22.1'、'1.20'。
+	Date     string `json:"date"`     // 发布日期，如'2024-03-05'。
+	Security bool   `json:"security"` // 是否包含安全修复
+	Notes    string `json:"notes"`    // 发布说明
+}
+
+var releasedRegexp = regexp.MustCompile(`^go(\S+) \(released (\d{4})[-/](\d{2})[-/](\d{2})\)\s*(.*)$`)
+
+// fetchTimeout 下载发布历史页面的超时时间，避免网络异常时命令长时间无响应。
+const fetchTimeout = 15 * time.Second
+
+// Fetch 下载并解析发布历史页面
+func Fetch(url string) (releases []*Release, err error) {
+	client := http.Client{Timeout: fetchTimeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, errs.NewURLUnreachableError(url, err)
+	}
+	defer resp.Body.Close()
+
+	if !httppkg.IsSuccess(resp.StatusCode) {
+		return nil, errs.NewURLUnreachableError(url, fmt.Errorf("%d", resp.StatusCode))
+	}
+	return Parse(resp.Body)
+}
+
+// Parse 解析发布历史页面，返回按版本号升序排列的发布列表。
+func Parse(r io.Reader) (releases []*Release, err error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	doc.Find("h2[id^=go], p[id^=go]").Each(func(i int, s *goquery.Selection) {
+		m := releasedRegexp.FindStringSubmatch(normalize(s.Text()))
+		if m == nil || seen[m[1]] {
+			return
+		}
+		if _, err := version.New(m[1]); err != nil {
+			return
+		}
+		seen[m[1]] = true
+
+		notes := m[5]
+		if goquery.NodeName(s) == "h2" {
+			// 主版本的说明位于标题后的段落中
+			notes = normalize(s.NextFiltered("p").Text())
+			if idx := strings.Index(notes, " Read the "); idx > 0 {
+				notes = notes[:idx]
+			}
+		} else if idx := strings.Index(notes, " See the "); idx > 0 {
+			notes = notes[:idx]
+		}
+
+		releases = append(releases, &Release{
+			Version:  m[1],
+			Date:     fmt.Sprintf("%s-%s-%s", m[2], m[3], m[4]),
+			Security: strings.Contains(notes, "security fix"),
+			Notes:    notes,
+		})
+	})
+
+	sort.SliceStable(releases, func(i, j int) bool {
+		return Less(releases[i].Version, releases[j].Version)
+	})
+	return releases, nil
+}
+
+// Find 返回指定版本的发布信息，未找到时返回nil。'1.21'与'1.21.0'视为同一版本。
+func Find(releases []*Release, vname string) *Release {
+	for _, r := range releases {
+		if r.Version == vname || (!Less(r.Version, vname) && !Less(vname, r.Version)) {
+			return r
+		}
+	}
+	return nil
+}
+
+// Between 返回版本号大于from且不大于to的发布列表（升序）。to为空表示不限上界。
+func Between(releases []*Release, from, to string) (items []*Release) {
+	for _, r := range releases {
+		if !Less(from, r.Version) {
+			continue
+		}
+		if to != "" && Less(to, r.Version) {
+			continue
+		}
+		items = append(items, r)
+	}
+	return items
+}
+
+// Less 返回版本a是否小于版本b，无法解析的版本号按字符串比较。
+func Less(a, b string) bool {
+	va, err1 := version.Semantify(a)
+	vb, err2 := version.Semantify(b)
+	if err1 != nil || err2 != nil {
+		return a < b
+	}
+	return va.LessThan(vb)
+}
+
+// normalize 合并文本中的连续空白字符
+func normalize(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
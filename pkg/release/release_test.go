@@ -0,0 +1,101 @@
+package release
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func versions(releases []*Release) []string {
+	out := make([]string, 0, len(releases))
+	for _, r := range releases {
+		out = append(out, r.Version)
+	}
+	return out
+}
+
+func TestParse(t *testing.T) {
+	f, err := os.Open("./testdata/release.html")
+	assert.Nil(t, err)
+	defer f.Close()
+
+	releases, err := Parse(f)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1.16", "1.16.1", "1.20", "1.20.1", "1.21.0", "1.21.1", "1.21.2", "1.21.3", "1.22.0", "1.22.1", "1.22.2"}, versions(releases))
+
+	t.Run("修订版本", func(t *testing.T) {
+		r := Find(releases, "1.22.2")
+		assert.NotNil(t, r)
+		assert.Equal(t, &Release{
+			Version:  "1.22.2",
+			Date:     "2024-04-03",
+			Security: true,
+			Notes:    "includes a security fix to the net/http package, as well as bug fixes to the compiler, the go command, the linker, and the encoding/gob, go/types, net/http, and runtime/trace packages.",
+		}, r)
+	})
+
+	t.Run("主版本", func(t *testing.T) {
+		r := Find(releases, "1.20.0")
+		assert.NotNil(t, r)
+		assert.Equal(t, &Release{
+			Version: "1.20",
+			Date:    "2023-02-01",
+			Notes:   "Go 1.20 is a major release of Go.",
+		}, r)
+	})
+
+	t.Run("旧格式的发布日期", func(t *testing.T) {
+		r := Find(releases, "1.16.1")
+		assert.NotNil(t, r)
+		assert.Equal(t, "2021-03-10", r.Date)
+		assert.False(t, r.Security)
+	})
+
+	t.Run("版本不存在", func(t *testing.T) {
+		assert.Nil(t, Find(releases, "1.19.1"))
+	})
+}
+
+func TestFetch(t *testing.T) {
+	srv := httptest.NewServer(http.FileServer(http.Dir("./testdata")))
+	defer srv.Close()
+
+	t.Run("下载并解析发布历史页面", func(t *testing.T) {
+		releases, err := Fetch(srv.URL + "/release.html")
+		assert.Nil(t, err)
+		assert.Equal(t, 11, len(releases))
+	})
+
+	t.Run("页面不存在", func(t *testing.T) {
+		_, err := Fetch(srv.URL + "/nonexistent.html")
+		assert.NotNil(t, err)
+	})
+}
+
+func TestBetween(t *testing.T) {
+	f, err := os.Open("./testdata/release.html")
+	assert.Nil(t, err)
+	defer f.Close()
+	releases, err := Parse(f)
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name string
+		from string
+		to   string
+		want []string
+	}{
+		{name: "同一次版本", from: "1.21.1", to: "1.21.3", want: []string{"1.21.2", "1.21.3"}},
+		{name: "跨次版本", from: "1.21.3", to: "1.22.1", want: []string{"1.22.0", "1.22.1"}},
+		{name: "不限上界", from: "1.22.0", want: []string{"1.22.1", "1.22.2"}},
+		{name: "无发布", from: "1.22.2", to: "1.22.2", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, versions(Between(releases, tt.from, tt.to)))
+		})
+	}
+}
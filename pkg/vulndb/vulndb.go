@@ -0,0 +1,259 @@
+package vulndb
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/voidint/g/pkg/errs"
+	httppkg "github.com/voidint/g/pkg/http"
+)
+
+// DefaultSource 官方Go漏洞数据库地址
+const DefaultSource = "https://vuln.go.dev"
+
+const (
+	// ModuleStdlib 标准库在漏洞数据库中的模块路径
+	ModuleStdlib = "stdlib"
+	// ModuleToolchain go命令等工具链在漏洞数据库中的模块路径
+	ModuleToolchain = "toolchain"
+)
+
+// concurrency 并发获取漏洞条目的数量
+const concurrency = 8
+
+// ModuleIndex 模块索引（index/modules.json）中的单个模块
+type ModuleIndex struct {
+	Path  string      `json:"path"`
+	Vulns []VulnIndex `json:"vulns"`
+}
+
+// VulnIndex 模块索引中的单个漏洞
+type VulnIndex struct {
+	ID       string    `json:"id"`
+	Modified time.Time `json:"modified"`
+	Fixed    string    `json:"fixed,omitempty"`
+}
+
+// Entry OSV格式的漏洞条目（ID/<id>.json）
+type Entry struct {
+	ID       string     `json:"id"`
+	Aliases  []string   `json:"aliases,omitempty"`
+	Summary  string     `json:"summary,omitempty"`
+	Affected []Affected `json:"affected"`
+}
+
+// Affected 受漏洞影响的模块及版本范围
+type Affected struct {
+	Package Package `json:"package"`
+	Ranges  []Range `json:"ranges,omitempty"`
+}
+
+// Package 受漏洞影响的模块
+type Package struct {
+	Name      string `json:"name"`
+	Ecosystem string `json:"ecosystem"`
+}
+
+// Range 受漏洞影响的版本范围
+type Range struct {
+	Type   string  `json:"type"`
+	Events []Event `json:"events"`
+}
+
+// Event 版本范围中的事件，Introduced与Fixed二者有且仅有一个。
+type Event struct {
+	Introduced string `json:"introduced,omitempty"`
+	Fixed      string `json:"fixed,omitempty"`
+}
+
+// DB Go漏洞数据库
+type DB struct {
+	source string
+	client *http.Client
+}
+
+// requestTimeout 单次请求远程数据库的超时时间，避免网络异常时命令长时间无响应。
+const requestTimeout = 30 * time.Second
+
+// New 返回漏洞数据库实例。source可以是数据库的URL（如'https://vuln.go.dev'）或与之目录结构相同的本地目录。
+func New(source string) *DB {
+	return &DB{
+		source: strings.TrimSuffix(source, "/"),
+		client: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// read 读取数据库中指定路径的文件，name以'/'分隔，如'index/modules.json'。
+func (db *DB) read(name string) (data []byte, err error) {
+	if !strings.HasPrefix(db.source, "http://") && !strings.HasPrefix(db.source, "https://") {
+		return os.ReadFile(filepath.Join(strings.TrimPrefix(db.source, "file://"), filepath.FromSlash(name)))
+	}
+
+	u := db.source + "/" + name
+	resp, err := db.client.Get(u)
+	if err != nil {
+		return nil, errs.NewURLUnreachableError(u, err)
+	}
+	defer resp.Body.Close()
+	if !httppkg.IsSuccess(resp.StatusCode) {
+		return nil, errs.NewURLUnreachableError(u, fmt.Errorf("%d", resp.StatusCode))
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// Modules 返回数据库的模块索引
+func (db *DB) Modules() (modules []ModuleIndex, err error) {
+	data, err := db.read("index/modules.json")
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &modules); err != nil {
+		return nil, fmt.Errorf("malformed module index: %w", err)
+	}
+	return modules, nil
+}
+
+// Entry 返回指定ID的漏洞条目
+func (db *DB) Entry(id string) (entry *Entry, err error) {
+	data, err := db.read("ID/" + id + ".json")
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("malformed entry %s: %w", id, err)
+	}
+	return entry, nil
+}
+
+// GoEntries 返回影响标准库或工具链的全部漏洞条目（按ID升序）
+func (db *DB) GoEntries() (entries []*Entry, err error) {
+	modules, err := db.Modules()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	seen := make(map[string]bool)
+	for _, m := range modules {
+		if m.Path != ModuleStdlib && m.Path != ModuleToolchain {
+			continue
+		}
+		for _, v := range m.Vulns {
+			if !seen[v.ID] {
+				seen[v.ID] = true
+				ids = append(ids, v.ID)
+			}
+		}
+	}
+	sort.Strings(ids)
+
+	entries = make([]*Entry, len(ids))
+	errCh := make(chan error, len(ids))
+	idxCh := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range idxCh {
+				entry, err := db.Entry(ids[idx])
+				if err != nil {
+					errCh <- err
+					continue
+				}
+				entries[idx] = entry
+			}
+		}()
+	}
+	for i := range ids {
+		idxCh <- i
+	}
+	close(idxCh)
+	wg.Wait()
+	close(errCh)
+
+	if err = <-errCh; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Affects 返回go版本（如'1.21.4'、'1.21rc2'）是否受漏洞影响，以及修复该漏洞的版本。受影响但尚无修复版本时fixed为空。
+func (e *Entry) Affects(goVersion string) (fixed string, affected bool) {
+	v, err := semver.NewVersion(GoToSemver(goVersion))
+	if err != nil {
+		return "", false
+	}
+	for _, a := range e.Affected {
+		if a.Package.Name != ModuleStdlib && a.Package.Name != ModuleToolchain {
+			continue
+		}
+		for _, r := range a.Ranges {
+			if r.Type != "SEMVER" {
+				continue
+			}
+			if fixed, affected = r.affects(v); affected {
+				return fixed, true
+			}
+		}
+	}
+	return "", false
+}
+
+// affects 返回版本是否落在该范围内，以及该范围内首个修复版本。
+func (r Range) affects(v *semver.Version) (fixed string, affected bool) {
+	type event struct {
+		v *semver.Version
+		Event
+	}
+	events := make([]event, 0, len(r.Events))
+	for _, e := range r.Events {
+		ev, err := semver.NewVersion(e.Introduced + e.Fixed)
+		if err != nil {
+			continue
+		}
+		events = append(events, event{v: ev, Event: e})
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].v.LessThan(events[j].v) })
+
+	for _, e := range events {
+		if !v.LessThan(e.v) {
+			affected = e.Introduced != ""
+			continue
+		}
+		if affected && e.Fixed != "" {
+			return e.Fixed, true
+		}
+		break
+	}
+	return "", affected
+}
+
+var goVersionRegexp = regexp.MustCompile(`^(\d+)\.(\d+)(?:\.(\d+))?(?:(alpha|beta|rc)(\d+))?$`)
+
+// GoToSemver 将go版本号转换为漏洞数据库所使用的语义化版本号，如'1.21'转换为'1.21.0'、'1.21rc2'转换为'1.21.0-rc.2'。无法识别时原样返回。
+func GoToSemver(goVersion string) string {
+	m := goVersionRegexp.FindStringSubmatch(strings.TrimPrefix(goVersion, "go"))
+	if m == nil {
+		return goVersion
+	}
+	patch := m[3]
+	if patch == "" {
+		patch = "0"
+	}
+	s := fmt.Sprintf("%s.%s.%s", m[1], m[2], patch)
+	if m[4] != "" {
+		s += fmt.Sprintf("-%s.%s", m[4], m[5])
+	}
+	return s
+}
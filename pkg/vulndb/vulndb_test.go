@@ -0,0 +1,86 @@
+package vulndb
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoEntries(t *testing.T) {
+	srv := httptest.NewServer(http.FileServer(http.Dir("./testdata")))
+	defer srv.Close()
+
+	ids := func(entries []*Entry) []string {
+		out := make([]string, 0, len(entries))
+		for _, e := range entries {
+			out = append(out, e.ID)
+		}
+		return out
+	}
+
+	t.Run("本地目录", func(t *testing.T) {
+		entries, err := New("./testdata").GoEntries()
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"GO-2023-1840", "GO-2023-2185", "GO-2024-2687"}, ids(entries))
+	})
+
+	t.Run("URL", func(t *testing.T) {
+		entries, err := New(srv.URL + "/").GoEntries()
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"GO-2023-1840", "GO-2023-2185", "GO-2024-2687"}, ids(entries))
+	})
+
+	t.Run("数据库不存在", func(t *testing.T) {
+		_, err := New(srv.URL + "/nonexistent").GoEntries()
+		assert.NotNil(t, err)
+	})
+}
+
+func TestEntry_Affects(t *testing.T) {
+	entry, err := New("./testdata").Entry("GO-2023-2185")
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name      string
+		goVersion string
+		fixed     string
+		affected  bool
+	}{
+		{name: "旧版本受影响", goVersion: "1.19.13", fixed: "1.20.11", affected: true},
+		{name: "1.20修订版本受影响", goVersion: "1.20.10", fixed: "1.20.11", affected: true},
+		{name: "1.20修复版本", goVersion: "1.20.11", affected: false},
+		{name: "1.21预发布版本受影响", goVersion: "1.21rc2", fixed: "1.21.4", affected: true},
+		{name: "1.21首个版本受影响", goVersion: "1.21.0", fixed: "1.21.4", affected: true},
+		{name: "1.21修复版本", goVersion: "1.21.4", affected: false},
+		{name: "更新的次版本", goVersion: "1.22.0", affected: false},
+		{name: "无法识别的版本", goVersion: "hello", affected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixed, affected := entry.Affects(tt.goVersion)
+			assert.Equal(t, tt.affected, affected)
+			assert.Equal(t, tt.fixed, fixed)
+		})
+	}
+}
+
+func TestGoToSemver(t *testing.T) {
+	tests := []struct {
+		goVersion string
+		want      string
+	}{
+		{goVersion: "1.21.4", want: "1.21.4"},
+		{goVersion: "go1.21.4", want: "1.21.4"},
+		{goVersion: "1.20", want: "1.20.0"},
+		{goVersion: "1.21rc2", want: "1.21.0-rc.2"},
+		{goVersion: "1.9beta1", want: "1.9.0-beta.1"},
+		{goVersion: "hello", want: "hello"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.goVersion, func(t *testing.T) {
+			assert.Equal(t, tt.want, GoToSemver(tt.goVersion))
+		})
+	}
+}
@@ -10,10 +10,11 @@ import (
 
 // Finder 版本查找器
 type Finder struct {
-	kind   PackageKind
-	goos   string
-	goarch string
-	items  []*Version
+	kind       PackageKind
+	goos       string
+	goarch     string
+	prerelease bool
+	items      []*Version
 }
 
 // WithFinderPackageKind 设置查找器查找的文件种类。
@@ -37,6 +38,13 @@ func WithFinderGoarch(goarch string) func(fdr *Finder) {
 	}
 }
 
+// WithFinderPrerelease 设置查找器在匹配通配符、^、~等版本约束时是否包含预发布版本（如'1.23rc1'）。默认不包含。
+func WithFinderPrerelease(prerelease bool) func(fdr *Finder) {
+	return func(fdr *Finder) {
+		fdr.prerelease = prerelease
+	}
+}
+
 // NewFinder 返回
 func NewFinder(items []*Version, opts ...func(fdr *Finder)) *Finder {
 	sort.Sort(Collection(items)) // 升序
@@ -60,7 +68,7 @@ func NewFinder(items []*Version, opts ...func(fdr *Finder)) *Finder {
 // Find 返回满足条件的语义化版本号。版本格式：主版本号.次版本号.修订号。
 // vname 支持以下几类版本标识：
 // 1、具体版本号：如'1.21.4'
-// 2、最新版本：latest
+// 2、版本通道：latest（最新稳定版本）、latest-unstable（最新版本，包括预发布版本）、oldstable（上一个次版本的最新稳定版本）、supported（官方仍在维护的最新稳定版本）
 // 3、通配符：如'1.21.x'、'1.x'、'1.18.*'等
 // 4、匹配最新的次版本号（主版本号兼容）：如'^1'、'^1.18'、'^1.18.10'等，在主版本号保持一致的前提下，次版本号和修订号均保持最新。
 // 5、匹配某个次版本号的最新修订号：如'~1.18'，在主次版本号保持一致的前提下，修订号保持最新。
@@ -68,35 +76,169 @@ func NewFinder(items []*Version, opts ...func(fdr *Finder)) *Finder {
 // 7、匹配小于目标版本的最新版本：如'<1.16'，小于该版本的前提下，匹配最大的版本号。
 // 8、匹配目标版本区间内的最新版本：如'1.18 - 1.20'，匹配该区间范围内的最大版本。
 func (fdr *Finder) Find(vname string) (*Version, error) {
-	if vname == Latest {
+	switch vname {
+	case Latest, Supported:
 		return fdr.findLatest()
+	case LatestUnstable:
+		return fdr.findNewest(vname, func(v *Version) bool { return true })
+	case OldStable:
+		return fdr.findOldStable()
 	}
 
 	for i := len(fdr.items) - 1; i >= 0; i-- {
-		if fdr.items[i].name == vname && fdr.items[i].match(fdr.goos, fdr.goarch) {
+		if fdr.items[i].name == vname && fdr.items[i].match(fdr.kind, fdr.goos, fdr.goarch) {
 			return fdr.items[i], nil
 		}
 	}
 
 	cs, err := semver.NewConstraint(vname)
 	if err != nil {
-		return nil, errs.NewVersionNotFoundError(vname, fdr.goos, fdr.goarch)
+		return nil, fdr.notFound(vname, fdr.named(vname))
 	}
 
-	versionFound := false
+	var found []*Version
 	for i := len(fdr.items) - 1; i >= 0; i-- { // 优先匹配高版本
-		if cs.Check(fdr.items[i].sv) {
-			versionFound = true
+		if fdr.check(cs, fdr.items[i]) {
+			found = append(found, fdr.items[i])
 
-			if fdr.items[i].match(fdr.goos, fdr.goarch) {
+			if fdr.items[i].match(fdr.kind, fdr.goos, fdr.goarch) {
 				return fdr.items[i], nil
 			}
 		}
 	}
-	if versionFound {
-		return nil, errs.NewPackageNotFoundError(string(fdr.kind), fdr.goos, fdr.goarch)
+	return nil, fdr.notFound(vname, found)
+}
+
+// FindAll 返回满足条件的全部版本，默认按升序排列。vname 支持 Find 所支持的各类版本标识，为空时返回全部版本。
+func (fdr *Finder) FindAll(vname string, opts ...func(o *FindAllOptions)) (items []*Version, err error) {
+	var o FindAllOptions
+	for _, setter := range opts {
+		if setter != nil {
+			setter(&o)
+		}
+	}
+
+	switch vname {
+	case Latest, LatestUnstable, OldStable:
+		v, err := fdr.Find(vname)
+		if err != nil {
+			return nil, err
+		}
+		items = []*Version{v}
+	case Supported:
+		if items, err = fdr.FindSupported(); err != nil {
+			return nil, err
+		}
+		reverse(items)
+	default:
+		accept := func(v *Version) bool { return true }
+		if vname != "" && !fdr.hasName(vname) {
+			cs, err := semver.NewConstraint(vname)
+			if err != nil {
+				return nil, fdr.notFound(vname, nil)
+			}
+			accept = func(v *Version) bool { return fdr.check(cs, v) }
+		} else if vname != "" {
+			accept = func(v *Version) bool { return v.name == vname }
+		}
+		if items, err = fdr.findAll(vname, accept); err != nil {
+			return nil, err
+		}
+	}
+
+	if o.latestPerMinor {
+		items = LatestPerMinor(items)
+	}
+	if o.desc {
+		reverse(items)
+	}
+	if o.limit > 0 && len(items) > o.limit {
+		items = items[:o.limit]
+	}
+	return items, nil
+}
+
+// FindAllOptions FindAll的可选项
+type FindAllOptions struct {
+	desc           bool
+	limit          int
+	latestPerMinor bool
+}
+
+// WithFindAllDesc 设置FindAll按降序返回版本
+func WithFindAllDesc() func(o *FindAllOptions) {
+	return func(o *FindAllOptions) {
+		o.desc = true
+	}
+}
+
+// WithFindAllLimit 设置FindAll最多返回的版本数量，在排序之后截取。
+func WithFindAllLimit(limit int) func(o *FindAllOptions) {
+	return func(o *FindAllOptions) {
+		o.limit = limit
+	}
+}
+
+// WithFindAllLatestPerMinor 设置FindAll每个次版本仅返回最新的修订版本
+func WithFindAllLatestPerMinor() func(o *FindAllOptions) {
+	return func(o *FindAllOptions) {
+		o.latestPerMinor = true
+	}
+}
+
+// LatestPerMinor 返回每个次版本中最高的版本，items须按升序排列。
+func LatestPerMinor(items []*Version) []*Version {
+	latest := make([]*Version, 0, len(items))
+	for _, v := range items {
+		if len(latest) > 0 && minorLineOf(latest[len(latest)-1]) == minorLineOf(v) {
+			latest[len(latest)-1] = v
+			continue
+		}
+		latest = append(latest, v)
+	}
+	return latest
+}
+
+func (fdr *Finder) hasName(vname string) bool {
+	return len(fdr.named(vname)) > 0
+}
+
+// named 返回名称为vname的版本
+func (fdr *Finder) named(vname string) (items []*Version) {
+	for _, v := range fdr.items {
+		if v.name == vname {
+			items = append(items, v)
+		}
+	}
+	return items
+}
+
+// findAll 按升序返回满足条件且包含目标软件包的全部版本
+func (fdr *Finder) findAll(vname string, accept func(v *Version) bool) (items []*Version, err error) {
+	var found []*Version
+	for _, v := range fdr.items {
+		if !accept(v) {
+			continue
+		}
+		found = append(found, v)
+
+		if v.match(fdr.kind, fdr.goos, fdr.goarch) {
+			items = append(items, v)
+		}
+	}
+	if len(items) > 0 {
+		return items, nil
+	}
+	if vname == "" {
+		vname = "*"
+	}
+	return nil, fdr.notFound(vname, found)
+}
+
+func reverse(items []*Version) {
+	for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+		items[i], items[j] = items[j], items[i]
 	}
-	return nil, errs.NewVersionNotFoundError(vname, fdr.goos, fdr.goarch)
 }
 
 // MustFind 返回满足条件的语义化版本号。若发生错误，则抛出panic。
@@ -108,18 +250,104 @@ func (fdr *Finder) MustFind(vname string) *Version {
 	return v
 }
 
-// Latest 指代当前最新版本
-const Latest = "latest"
+const (
+	// Latest 指代最新的稳定版本
+	Latest = "latest"
+	// LatestUnstable 指代最新版本，包括预发布版本。
+	LatestUnstable = "latest-unstable"
+	// OldStable 指代上一个次版本的最新稳定版本
+	OldStable = "oldstable"
+	// Supported 指代官方仍在维护的两个次版本，Find返回其中最新的稳定版本。
+	Supported = "supported"
+)
+
+// check 检查版本是否满足约束。开启预发布版本后，预发布版本按其正式版本号参与匹配，如'1.23rc1'满足'1.23.x'。
+func (fdr *Finder) check(cs *semver.Constraints, v *Version) bool {
+	if cs.Check(v.sv) {
+		return true
+	}
+	if !fdr.prerelease || v.sv.Prerelease() == "" {
+		return false
+	}
+	sv, err := v.sv.SetPrerelease("")
+	return err == nil && cs.Check(&sv)
+}
 
 func (fdr *Finder) findLatest() (*Version, error) {
-	if len(fdr.items) == 0 {
-		return nil, errs.NewVersionNotFoundError(Latest, fdr.goos, fdr.goarch)
+	return fdr.findNewest(Latest, isStable)
+}
+
+func (fdr *Finder) findOldStable() (*Version, error) {
+	lines := fdr.stableMinorLines()
+	if len(lines) < 2 {
+		return nil, errs.NewVersionNotFoundError(OldStable, fdr.goos, fdr.goarch)
 	}
+	return fdr.findNewest(OldStable, func(v *Version) bool {
+		return isStable(v) && minorLineOf(v) == lines[1]
+	})
+}
 
+// FindSupported 返回官方仍在维护的两个次版本（即最新的两个次版本）各自的最新稳定版本（降序）。
+func (fdr *Finder) FindSupported() (items []*Version, err error) {
+	lines := fdr.stableMinorLines()
+	if len(lines) > 2 {
+		lines = lines[:2]
+	}
+	for _, line := range lines {
+		line := line
+		v, err := fdr.findNewest(Supported, func(v *Version) bool {
+			return isStable(v) && minorLineOf(v) == line
+		})
+		if err != nil {
+			continue
+		}
+		items = append(items, v)
+	}
+	if len(items) == 0 {
+		return nil, errs.NewVersionNotFoundError(Supported, fdr.goos, fdr.goarch)
+	}
+	return items, nil
+}
+
+// findNewest 返回满足条件且包含目标软件包的最高版本
+func (fdr *Finder) findNewest(vname string, accept func(v *Version) bool) (*Version, error) {
+	versionFound := false
 	for i := len(fdr.items) - 1; i >= 0; i-- {
-		if fdr.items[i].match(fdr.goos, fdr.goarch) {
+		if !accept(fdr.items[i]) {
+			continue
+		}
+		versionFound = true
+
+		if fdr.items[i].match(fdr.kind, fdr.goos, fdr.goarch) {
 			return fdr.items[i], nil
 		}
 	}
-	return nil, errs.NewPackageNotFoundError(string(fdr.kind), fdr.goos, fdr.goarch)
+	if versionFound {
+		return nil, errs.NewPackageNotFoundError(string(fdr.kind), fdr.goos, fdr.goarch)
+	}
+	return nil, errs.NewVersionNotFoundError(vname, fdr.goos, fdr.goarch)
+}
+
+// stableMinorLines 返回稳定版本所属的次版本列表（降序）
+func (fdr *Finder) stableMinorLines() (lines []minorLine) {
+	for i := len(fdr.items) - 1; i >= 0; i-- {
+		if !isStable(fdr.items[i]) {
+			continue
+		}
+		if line := minorLineOf(fdr.items[i]); len(lines) == 0 || lines[len(lines)-1] != line {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// minorLine 次版本，如'1.21.4'所属的次版本为{1, 21}。
+type minorLine [2]uint64
+
+func minorLineOf(v *Version) minorLine {
+	return minorLine{v.sv.Major(), v.sv.Minor()}
+}
+
+func isStable(v *Version) bool {
+	return v.sv.Prerelease() == ""
 }
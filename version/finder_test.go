@@ -2,8 +2,10 @@ package version
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -296,3 +298,158 @@ func TestFinder_findLatest(t *testing.T) {
 		})
 	}
 }
+
+func TestFinder_channels(t *testing.T) {
+	newVersion := func(vname string) *Version {
+		return MustNew(vname, WithPackages([]*Package{
+			{FileName: "go" + vname + ".darwin-arm64.tar.gz", Kind: ArchiveKind},
+		}))
+	}
+	vs := []*Version{
+		newVersion("1.20.11"),
+		newVersion("1.21.3"),
+		newVersion("1.21.4"),
+		newVersion("1.22rc1"),
+		newVersion("1.20.10"),
+	}
+	fdr := NewFinder(vs, WithFinderGoos("darwin"), WithFinderGoarch("arm64"))
+
+	tests := []struct {
+		name    string
+		fdr     *Finder
+		vname   string
+		want    string
+		wantErr bool
+	}{
+		{name: "latest不包含预发布版本", fdr: fdr, vname: Latest, want: "1.21.4"},
+		{name: "latest-unstable包含预发布版本", fdr: fdr, vname: LatestUnstable, want: "1.22rc1"},
+		{name: "oldstable为上一个次版本的最新稳定版本", fdr: fdr, vname: OldStable, want: "1.20.11"},
+		{name: "supported为最新稳定版本", fdr: fdr, vname: Supported, want: "1.21.4"},
+		{name: "通配符默认不匹配预发布版本", fdr: fdr, vname: "1.22.x", wantErr: true},
+		{name: "通配符匹配预发布版本", fdr: NewFinder(vs, WithFinderGoos("darwin"), WithFinderGoarch("arm64"), WithFinderPrerelease(true)), vname: "1.22.x", want: "1.22rc1"},
+		{name: "^匹配预发布版本", fdr: NewFinder(vs, WithFinderGoos("darwin"), WithFinderGoarch("arm64"), WithFinderPrerelease(true)), vname: "^1.21", want: "1.22rc1"},
+		{name: "仅有一个次版本时不存在oldstable", fdr: NewFinder([]*Version{newVersion("1.21.4")}, WithFinderGoos("darwin"), WithFinderGoarch("arm64")), vname: OldStable, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fdr.Find(tt.vname)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got.Name())
+		})
+	}
+}
+
+func TestFinder_FindSupported(t *testing.T) {
+	vs, err := genVersions()
+	assert.Nil(t, err)
+
+	t.Run("查找官方仍在维护的版本", func(t *testing.T) {
+		items, err := NewFinder(vs, WithFinderGoos("darwin"), WithFinderGoarch("arm64")).FindSupported()
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(items))
+		assert.Equal(t, "1.21.4", items[0].Name())
+		assert.Equal(t, "1.20.11", items[1].Name())
+	})
+
+	t.Run("版本列表为空", func(t *testing.T) {
+		_, err := NewFinder(nil).FindSupported()
+		assert.NotNil(t, err)
+	})
+}
+
+func TestFinder_FindAll(t *testing.T) {
+	vs, err := genVersions()
+	assert.Nil(t, err)
+
+	fdr := NewFinder(vs, WithFinderGoos("darwin"), WithFinderGoarch("arm64"))
+	names := func(items []*Version) []string {
+		out := make([]string, 0, len(items))
+		for _, v := range items {
+			out = append(out, v.Name())
+		}
+		return out
+	}
+
+	tests := []struct {
+		name    string
+		vname   string
+		opts    []func(o *FindAllOptions)
+		want    []string
+		wantErr bool
+	}{
+		{name: "版本约束", vname: "~1.21", want: []string{"1.21.0", "1.21.1", "1.21.2", "1.21.3", "1.21.4"}},
+		{name: "具体版本号", vname: "1.20", want: []string{"1.20"}},
+		{name: "降序", vname: ">= 1.21.3", opts: []func(o *FindAllOptions){WithFindAllDesc()}, want: []string{"1.21.4", "1.21.3"}},
+		{name: "降序并限制数量", vname: "^1.20", opts: []func(o *FindAllOptions){WithFindAllDesc(), WithFindAllLimit(3)}, want: []string{"1.21.4", "1.21.3", "1.21.2"}},
+		{name: "每个次版本仅保留最新的修订版本", vname: ">= 1.19", opts: []func(o *FindAllOptions){WithFindAllLatestPerMinor()}, want: []string{"1.19.13", "1.20.11", "1.21.4"}},
+		{name: "版本通道", vname: Supported, want: []string{"1.20.11", "1.21.4"}},
+		{name: "没有合适的软件包", vname: "<1.16", wantErr: true},
+		{name: "非法版本号", vname: "voidint", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fdr.FindAll(tt.vname, tt.opts...)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, names(got))
+		})
+	}
+}
+
+func TestFinder_suggestions(t *testing.T) {
+	vs, err := genVersions()
+	assert.Nil(t, err)
+
+	fdr := NewFinder(vs, WithFinderGoos("darwin"), WithFinderGoarch("arm64"))
+
+	tests := []struct {
+		name  string
+		vname string
+		check func(err error) bool
+		want  []string
+	}{
+		{
+			name:  "建议同一次版本中的版本",
+			vname: "1.21.5",
+			check: errs.IsVersionNotFound,
+			want:  []string{"1.21.4 (latest 1.21)", "1.21.3", "1.21.2"},
+		},
+		{
+			name:  "建议其他硬件架构的软件包",
+			vname: "1.12.4",
+			check: errs.IsPackageNotFound,
+			want:  []string{"1.12.4 (darwin/amd64)"},
+		},
+		{
+			name:  "建议拼写相近的版本",
+			vname: "1.211.4",
+			check: errs.IsVersionNotFound,
+			want:  []string{"1.21.4", "1.21.3", "1.21.2"},
+		},
+		{
+			name:  "非法版本号无建议",
+			vname: "voidint",
+			check: errs.IsVersionNotFound,
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := fdr.Find(tt.vname)
+			assert.True(t, tt.check(err))
+
+			if tt.want == nil {
+				assert.NotContains(t, err.Error(), "did you mean")
+			} else {
+				assert.True(t, strings.HasSuffix(err.Error(), fmt.Sprintf(", did you mean: %s?", strings.Join(tt.want, ", "))), err.Error())
+			}
+		})
+	}
+}
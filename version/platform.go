@@ -0,0 +1,94 @@
+package version
+
+import (
+	"regexp"
+	"strings"
+)
+
+// knownGoos 安装包文件名中可能出现的操作系统（GOOS）
+var knownGoos = map[string]bool{
+	"aix":       true,
+	"android":   true,
+	"darwin":    true,
+	"dragonfly": true,
+	"freebsd":   true,
+	"illumos":   true,
+	"ios":       true,
+	"js":        true,
+	"linux":     true,
+	"netbsd":    true,
+	"openbsd":   true,
+	"plan9":     true,
+	"solaris":   true,
+	"wasip1":    true,
+	"windows":   true,
+}
+
+// knownGoarch 安装包文件名中可能出现的硬件架构（GOARCH）
+var knownGoarch = map[string]bool{
+	"386":      true,
+	"amd64":    true,
+	"arm":      true,
+	"arm64":    true,
+	"loong64":  true,
+	"mips":     true,
+	"mipsle":   true,
+	"mips64":   true,
+	"mips64le": true,
+	"ppc64":    true,
+	"ppc64le":  true,
+	"riscv64":  true,
+	"s390x":    true,
+	"wasm":     true,
+}
+
+// IsKnownGoarch 返回是否为安装包文件名中可能出现的硬件架构（GOARCH），如'386'、'arm64'。
+func IsKnownGoarch(goarch string) bool {
+	return knownGoarch[goarch]
+}
+
+// archVariants 安装包文件名中带变体的硬件架构与GOARCH及变体的对应关系
+var archVariants = map[string][2]string{
+	"armv6l": {"arm", "v6l"},
+	"arm6":   {"arm", "v6"},
+}
+
+// platformRegexp 匹配安装包文件名中的平台部分，如'go1.21.4.linux-armv6l.tar.gz'、'go1.2.2.darwin-amd64-osx10.8.pkg'。
+var platformRegexp = regexp.MustCompile(`\.([a-z0-9]+)-([a-z0-9]+)(-[a-z0-9.]+)?\.(tar\.gz|zip|pkg|msi)$`)
+
+// ParsePlatform 从安装包文件名中解析出规范化的操作系统（GOOS）、硬件架构（GOARCH）及变体。
+// 如'go1.21.4.linux-armv6l.tar.gz'将被解析为linux、arm、v6l。源码包等与平台无关的文件将返回空字符串。
+func ParsePlatform(filename string) (goos, goarch, variant string) {
+	matches := platformRegexp.FindStringSubmatch(filename)
+	if len(matches) == 0 || !knownGoos[matches[1]] {
+		return "", "", ""
+	}
+
+	goos, goarch = matches[1], matches[2]
+	if v, ok := archVariants[goarch]; ok {
+		goarch, variant = v[0], v[1]
+	} else if !knownGoarch[goarch] {
+		return "", "", ""
+	}
+
+	if suffix := strings.TrimPrefix(matches[3], "-"); suffix != "" {
+		if variant != "" {
+			variant += "-"
+		}
+		variant += suffix
+	}
+	return goos, goarch, variant
+}
+
+// ParseVersionName 从安装包文件名中解析出版本号，如'go1.21.4.linux-amd64.tar.gz'将被解析为'1.21.4'。无法解析时返回空字符串。
+func ParseVersionName(filename string) string {
+	goos, _, _ := ParsePlatform(filename)
+	if goos == "" || !strings.HasPrefix(filename, "go") {
+		return ""
+	}
+	idx := strings.Index(filename, "."+goos+"-")
+	if idx <= len("go") {
+		return ""
+	}
+	return filename[len("go"):idx]
+}
@@ -0,0 +1,99 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePlatform(t *testing.T) {
+	tests := []struct {
+		name        string
+		filename    string
+		wantGoos    string
+		wantGoarch  string
+		wantVariant string
+	}{
+		{
+			name:       "普通压缩包",
+			filename:   "go1.21.4.linux-amd64.tar.gz",
+			wantGoos:   "linux",
+			wantGoarch: "amd64",
+		},
+		{
+			name:       "linux-arm与linux-arm64不混淆",
+			filename:   "go1.21.4.linux-arm64.tar.gz",
+			wantGoos:   "linux",
+			wantGoarch: "arm64",
+		},
+		{
+			name:        "armv6l变体",
+			filename:    "go1.21.4.linux-armv6l.tar.gz",
+			wantGoos:    "linux",
+			wantGoarch:  "arm",
+			wantVariant: "v6l",
+		},
+		{
+			name:        "arm6变体",
+			filename:    "go1.5.linux-arm6.tar.gz",
+			wantGoos:    "linux",
+			wantGoarch:  "arm",
+			wantVariant: "v6",
+		},
+		{
+			name:        "macOS系统版本变体",
+			filename:    "go1.2.2.darwin-amd64-osx10.8.pkg",
+			wantGoos:    "darwin",
+			wantGoarch:  "amd64",
+			wantVariant: "osx10.8",
+		},
+		{
+			name:       "可安装程序",
+			filename:   "go1.21.4.windows-386.msi",
+			wantGoos:   "windows",
+			wantGoarch: "386",
+		},
+		{
+			name:     "源码包",
+			filename: "go1.21.4.src.tar.gz",
+		},
+		{
+			name:     "自举包",
+			filename: "go1.4-bootstrap-20170531.tar.gz",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			goos, goarch, variant := ParsePlatform(tt.filename)
+			assert.Equal(t, tt.wantGoos, goos)
+			assert.Equal(t, tt.wantGoarch, goarch)
+			assert.Equal(t, tt.wantVariant, variant)
+		})
+	}
+}
+
+func TestParseVersionName(t *testing.T) {
+	assert.Equal(t, "1.21.4", ParseVersionName("go1.21.4.linux-amd64.tar.gz"))
+	assert.Equal(t, "1.22rc1", ParseVersionName("go1.22rc1.windows-arm64.zip"))
+	assert.Equal(t, "1.2.2", ParseVersionName("go1.2.2.darwin-amd64-osx10.8.tar.gz"))
+	assert.Equal(t, "", ParseVersionName("go1.21.4.src.tar.gz"))
+	assert.Equal(t, "", ParseVersionName("go.linux-amd64.tar.gz"))
+	assert.Equal(t, "", ParseVersionName("golang.tar.gz"))
+}
+
+func TestPackage_Match(t *testing.T) {
+	pkg := &Package{
+		FileName: "go1.21.4.linux-armv6l.tar.gz",
+		Kind:     ArchiveKind,
+		GOOS:     "linux",
+		GOARCH:   "arm",
+		Variant:  "v6l",
+	}
+
+	assert.True(t, pkg.Match(ArchiveKind, "linux", "arm"))
+	assert.True(t, pkg.Match("", "linux", ""))
+	assert.True(t, pkg.Match("archive", "", "arm"))
+	assert.False(t, pkg.Match(InstallerKind, "linux", "arm"))
+	assert.False(t, pkg.Match(ArchiveKind, "linux", "arm64"))
+	assert.False(t, pkg.Match(ArchiveKind, "darwin", "arm"))
+}
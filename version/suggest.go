@@ -0,0 +1,160 @@
+package version
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/voidint/g/pkg/errs"
+)
+
+// suggestLimit 建议的候选版本数量上限
+const suggestLimit = 3
+
+// notFound 返回附带候选版本建议的错误。found为满足条件但缺少目标软件包的版本（降序），非空时返回软件包不存在错误。
+func (fdr *Finder) notFound(vname string, found []*Version) error {
+	if len(found) > 0 {
+		return errs.WithSuggestions(errs.NewPackageNotFoundError(string(fdr.kind), fdr.goos, fdr.goarch), fdr.suggestPlatforms(found)...)
+	}
+	return errs.WithSuggestions(errs.NewVersionNotFoundError(vname, fdr.goos, fdr.goarch), fdr.suggest(vname)...)
+}
+
+// suggestPlatforms 返回版本在其他平台上的软件包，如'1.21.4 (linux/arm64)'。
+func (fdr *Finder) suggestPlatforms(found []*Version) (suggestions []string) {
+	sort.Sort(sort.Reverse(Collection(found)))
+	for _, v := range found {
+		for _, pkg := range v.pkgs {
+			if pkg == nil || pkg.GOOS == "" || !pkg.Match(fdr.kind, "", "") {
+				continue
+			}
+			if fdr.goos != "" && pkg.GOOS != fdr.goos {
+				continue // 仅建议当前操作系统下的其他硬件架构
+			}
+			item := fmt.Sprintf("%s (%s/%s)", v.name, pkg.GOOS, pkg.GOARCH)
+			if !contains(suggestions, item) {
+				suggestions = append(suggestions, item)
+			}
+			if len(suggestions) >= suggestLimit {
+				return suggestions
+			}
+		}
+	}
+	return suggestions
+}
+
+// suggest 返回与vname相近且包含目标软件包的候选版本。依次考虑：同一次版本中的版本、拼写相近的版本、语义上最接近的版本。
+func (fdr *Finder) suggest(vname string) []string {
+	target, _ := Semantify(trimConstraint(vname))
+
+	type candidate struct {
+		v     *Version
+		score float64
+		note  string
+	}
+	var candidates []candidate
+	var lineLatest *Version
+	for _, v := range fdr.items {
+		if !v.match(fdr.kind, fdr.goos, fdr.goarch) {
+			continue
+		}
+		c := candidate{v: v}
+		switch d := levenshtein(vname, v.name); {
+		case target != nil && sameMinor(target, v.sv):
+			c.score = 1 + distance(target, v.sv)
+			if isStable(v) && (lineLatest == nil || lineLatest.sv.LessThan(v.sv)) {
+				lineLatest = v
+			}
+		case d <= 2:
+			c.score = float64(2 + d)
+		case target != nil:
+			c.score = 10 + distance(target, v.sv)
+		default:
+			continue
+		}
+		candidates = append(candidates, c)
+	}
+
+	for i := range candidates {
+		if candidates[i].v == lineLatest {
+			candidates[i].score = 0
+			candidates[i].note = fmt.Sprintf(" (latest %d.%d)", lineLatest.sv.Major(), lineLatest.sv.Minor())
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score < candidates[j].score
+		}
+		return candidates[j].v.sv.LessThan(candidates[i].v.sv)
+	})
+
+	suggestions := make([]string, 0, suggestLimit)
+	for i := 0; i < len(candidates) && i < suggestLimit; i++ {
+		suggestions = append(suggestions, candidates[i].v.name+candidates[i].note)
+	}
+	return suggestions
+}
+
+// trimConstraint 去除版本约束中的运算符及通配符，如'~1.21.x'将返回'1.21'。
+func trimConstraint(vname string) string {
+	vname = strings.TrimLeft(strings.TrimSpace(vname), "~^<>=!v ")
+	for _, suffix := range []string{".x", ".X", ".*"} {
+		vname = strings.TrimSuffix(vname, suffix)
+	}
+	return vname
+}
+
+func sameMinor(a, b *semver.Version) bool {
+	return a.Major() == b.Major() && a.Minor() == b.Minor()
+}
+
+// distance 返回两个版本之间的距离，次版本号的差距优先于修订号的差距。
+func distance(a, b *semver.Version) float64 {
+	abs := func(x, y uint64) float64 {
+		if x > y {
+			return float64(x - y)
+		}
+		return float64(y - x)
+	}
+	return abs(a.Major(), b.Major())*100 + abs(a.Minor(), b.Minor()) + abs(a.Patch(), b.Patch())/100
+}
+
+// levenshtein 返回两个字符串之间的编辑距离
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func minInt(items ...int) int {
+	m := items[0]
+	for _, item := range items[1:] {
+		if item < m {
+			m = item
+		}
+	}
+	return m
+}
+
+func contains(items []string, s string) bool {
+	for _, item := range items {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package version
+
+import (
+	"strings"
+
+	"github.com/voidint/g/pkg/errs"
+)
+
+// LanguageConstraint 返回go.mod（go.work）中go指令声明的语言版本所对应的版本约束。
+// 语言版本仅是对工具链的最低要求，如'1.21'将转换为'>= 1.21.0'。
+func LanguageConstraint(lang string) (string, error) {
+	sv, err := Semantify(lang)
+	if err != nil {
+		return "", err
+	}
+	return ">= " + sv.String(), nil
+}
+
+// ParseToolchain 返回toolchain指令中工具链名称所对应的具体版本号，如'go1.22.3'、'go1.22.3+auto'均将返回'1.22.3'。
+func ParseToolchain(name string) (string, error) {
+	if !strings.HasPrefix(name, "go") {
+		return "", errs.NewMalformedVersionError(name, nil)
+	}
+	vname := strings.TrimPrefix(name, "go")
+	if idx := strings.IndexAny(vname, "+-"); idx > 0 { // 如'go1.22.3+auto'、'go1.22.3-custom'
+		vname = vname[:idx]
+	}
+	if _, err := Semantify(vname); err != nil {
+		return "", errs.NewMalformedVersionError(name, err)
+	}
+	return vname, nil
+}
+
+// ToolchainVersion 返回满足go.mod（go.work）中go指令和toolchain指令要求的版本标识。
+// toolchain指令指定的是具体版本，但当其低于go指令声明的语言版本时将被忽略（与go命令的行为一致），此时返回语言版本对应的版本约束。
+func ToolchainVersion(lang, toolchain string) (string, error) {
+	if toolchain != "" && toolchain != "default" {
+		vname, err := ParseToolchain(toolchain)
+		if err != nil {
+			return "", err
+		}
+		if lang == "" {
+			return vname, nil
+		}
+		langV, err := Semantify(lang)
+		if err != nil {
+			return "", err
+		}
+		if tv, _ := Semantify(vname); !tv.LessThan(langV) {
+			return vname, nil
+		}
+	}
+	if lang == "" {
+		return "", errs.NewMalformedVersionError(lang, nil)
+	}
+	return LanguageConstraint(lang)
+}
@@ -0,0 +1,68 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/voidint/g/pkg/errs"
+)
+
+func TestLanguageConstraint(t *testing.T) {
+	cs, err := LanguageConstraint("1.21")
+	assert.Nil(t, err)
+	assert.Equal(t, ">= 1.21.0", cs)
+
+	cs, err = LanguageConstraint("1.22.1")
+	assert.Nil(t, err)
+	assert.Equal(t, ">= 1.22.1", cs)
+
+	_, err = LanguageConstraint("voidint")
+	assert.True(t, errs.IsMalformedVersion(err))
+}
+
+func TestParseToolchain(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{name: "具体版本", in: "go1.22.3", want: "1.22.3"},
+		{name: "预发布版本", in: "go1.23rc1", want: "1.23rc1"},
+		{name: "带+auto后缀", in: "go1.22.3+auto", want: "1.22.3"},
+		{name: "带自定义后缀", in: "go1.22.3-custom", want: "1.22.3"},
+		{name: "缺少go前缀", in: "1.22.3", wantErr: true},
+		{name: "default", in: "default", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseToolchain(tt.in)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestToolchainVersion(t *testing.T) {
+	tests := []struct {
+		name      string
+		lang      string
+		toolchain string
+		want      string
+		wantErr   bool
+	}{
+		{name: "仅有go指令", lang: "1.21", want: ">= 1.21.0"},
+		{name: "toolchain指令优先", lang: "1.21", toolchain: "go1.22.3", want: "1.22.3"},
+		{name: "仅有toolchain指令", toolchain: "go1.22.3", want: "1.22.3"},
+		{name: "toolchain低于语言版本", lang: "1.22.1", toolchain: "go1.21.4", want: ">= 1.22.1"},
+		{name: "toolchain为default", lang: "1.21.0", toolchain: "default", want: ">= 1.21.0"},
+		{name: "均为空", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToolchainVersion(tt.lang, tt.toolchain)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,7 +1,7 @@
 package version
 
 import (
-	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -66,6 +66,11 @@ func New(name string, opts ...func(v *Version)) (*Version, error) {
 		setter(&v)
 	}
 
+	for _, pkg := range v.pkgs {
+		if pkg != nil && pkg.GOOS == "" {
+			pkg.GOOS, pkg.GOARCH, pkg.Variant = ParsePlatform(pkg.FileName)
+		}
+	}
 	return &v, nil
 }
 
@@ -96,9 +101,9 @@ func (v *Version) MatchConstraint(c *semver.Constraints) bool {
 	return c.Check(v.sv)
 }
 
-func (v *Version) match(goos, goarch string) bool {
+func (v *Version) match(kind PackageKind, goos, goarch string) bool {
 	for _, pkg := range v.pkgs {
-		if strings.Contains(pkg.FileName, goos) && strings.Contains(pkg.FileName, goarch) { // TODO 不够严谨
+		if pkg != nil && pkg.Match(kind, goos, goarch) {
 			return true
 		}
 	}
@@ -107,9 +112,8 @@ func (v *Version) match(goos, goarch string) bool {
 
 // FindPackages 返回指定操作系统和硬件架构的版本包
 func (v *Version) FindPackages(kind PackageKind, goos, goarch string) (pkgs []Package, err error) {
-	prefix := fmt.Sprintf("go%s.%s-%s", v.name, goos, goarch)
 	for i := range v.pkgs {
-		if v.pkgs[i] == nil || !strings.EqualFold(string(v.pkgs[i].Kind), string(kind)) || !strings.HasPrefix(v.pkgs[i].FileName, prefix) {
+		if v.pkgs[i] == nil || !v.pkgs[i].Match(kind, goos, goarch) {
 			continue
 		}
 		pkgs = append(pkgs, *v.pkgs[i])
@@ -127,6 +131,9 @@ type Package struct {
 	Kind        PackageKind `json:"kind"`
 	OS          string      `json:"os"`
 	Arch        string      `json:"arch"`
+	GOOS        string      `json:"goos,omitempty"`    // 规范化的操作系统，如linux、darwin。
+	GOARCH      string      `json:"goarch,omitempty"`  // 规范化的硬件架构，如amd64、arm。
+	Variant     string      `json:"variant,omitempty"` // 硬件架构变体，如armv6l的v6l。
 	Size        string      `json:"size"`
 	Checksum    string      `json:"checksum"`
 	ChecksumURL string      `json:"-"`
@@ -145,11 +152,27 @@ const (
 	InstallerKind PackageKind = "Installer"
 )
 
+// Match 检查安装包是否属于指定的种类、操作系统和硬件架构。参数为空时表示不限制该条件。
+func (pkg *Package) Match(kind PackageKind, goos, goarch string) bool {
+	if kind != "" && !strings.EqualFold(string(pkg.Kind), string(kind)) {
+		return false
+	}
+	if goos != "" && pkg.GOOS != goos {
+		return false
+	}
+	return goarch == "" || pkg.GOARCH == goarch
+}
+
 // DownloadWithProgress 下载版本另存为指定文件且显示下载进度
 func (pkg *Package) DownloadWithProgress(dst string) (size int64, err error) {
 	return httppkg.Download(pkg.URL, dst, os.O_CREATE|os.O_WRONLY, 0644, true)
 }
 
+// DownloadWithProgressTo 下载版本另存为指定文件，下载进度输出至progress。
+func (pkg *Package) DownloadWithProgressTo(dst string, progress io.Writer) (size int64, err error) {
+	return httppkg.DownloadTo(pkg.URL, dst, os.O_CREATE|os.O_WRONLY, 0644, progress)
+}
+
 // VerifyChecksum 验证目标文件的校验和与当前安装包的校验和是否一致
 func (pkg *Package) VerifyChecksum(filename string) (err error) {
 	if pkg.Checksum == "" && pkg.ChecksumURL != "" {
@@ -139,11 +139,24 @@ func TestVersion_FindPackages(t *testing.T) {
 	})
 
 	t.Run("查找到多个软件包", func(t *testing.T) {
+		v122 := NewFinder(vs).MustFind("1.2.2")
+
+		pkgs, err := v122.FindPackages(ArchiveKind, "darwin", "amd64")
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(pkgs))
+		assert.Equal(t, "osx10.6", pkgs[0].Variant)
+		assert.Equal(t, "osx10.8", pkgs[1].Variant)
+	})
+
+	t.Run("查找到带架构变体的软件包", func(t *testing.T) {
 		v1214 := vs[len(vs)-1] // 1.21.4
 
 		pkgs, err := v1214.FindPackages(ArchiveKind, "linux", "arm")
 		assert.Nil(t, err)
-		assert.Equal(t, 2, len(pkgs))
+		assert.Equal(t, 1, len(pkgs))
+		assert.Equal(t, "go1.21.4.linux-armv6l.tar.gz", pkgs[0].FileName)
+		assert.Equal(t, "arm", pkgs[0].GOARCH)
+		assert.Equal(t, "v6l", pkgs[0].Variant)
 	})
 
 	t.Run("未查找到软件包", func(t *testing.T) {
@@ -196,6 +209,8 @@ func TestVersion_Packages(t *testing.T) {
 					Kind:      kind,
 					OS:        os,
 					Arch:      arch,
+					GOOS:      "darwin",
+					GOARCH:    "arm64",
 					Size:      size,
 					Checksum:  checksum,
 					Algorithm: algorithm,